@@ -0,0 +1,19 @@
+// Command httpcode looks up HTTP status codes and methods.
+//
+// Usage:
+//
+//	httpcode [--json] <code|phrase>     Show a status code
+//	httpcode [--json] search <query>    Search status codes and methods
+//	httpcode [--json] class <class>     List the status codes of a class (1xx-5xx)
+//	httpcode [--json] method <name>     Show a method
+package main
+
+import (
+	"os"
+
+	"github.com/JuniorVieira99/jr_httpcodes/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
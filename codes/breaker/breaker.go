@@ -0,0 +1,344 @@
+// Package breaker provides a circuit breaker http.RoundTripper that
+// classifies failures by status code.
+//
+// The breaker is closed while the failure ratio of a rolling window stays
+// below a threshold. It then opens and rejects requests, until a timeout
+// lets a few probe requests through in the half-open state, closing the
+// breaker again if they succeed.
+//
+// Example:
+//
+//	client := &http.Client{
+//	    Transport: breaker.New(http.DefaultTransport, breaker.Options{
+//	        OnStateChange: func(from, to breaker.State) {
+//	            log.Printf("orders API circuit %s -> %s", from, to)
+//	        },
+//	    }),
+//	}
+//
+//	resp, err := client.Get("https://orders.example.com/orders/42")
+//	if errors.Is(err, breaker.ErrOpen) {
+//	    // Fail fast, codes.FromError(err) returns 503
+//	}
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+)
+
+// Breaker Types
+// --------------------------------------------------------------------
+
+// State is the state of a circuit breaker.
+type State int
+
+// Breaker States
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+// Options configures a Breaker. Zero fields use the defaults.
+type Options struct {
+	// FailureClasses and FailureCodes are the status codes counted as
+	// failures, 5xx and 429 when both are empty. Other codes, such as 4xx,
+	// count as successes. Transport errors are failures, except canceled requests.
+	FailureClasses []codes.Class
+	FailureCodes   []codes.StatusCode
+	// Window is the duration of the rolling window, 10s by default.
+	Window time.Duration
+	// Buckets is the number of buckets of the window, 10 by default.
+	Buckets int
+	// MinRequests is the number of requests in the window needed to open
+	// the breaker, 20 by default.
+	MinRequests int
+	// FailureRatio opens the breaker when reached, 0.5 by default.
+	FailureRatio float64
+	// OpenTimeout is the time spent open before probing, 30s by default.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of probe requests, all of which must
+	// succeed to close the breaker, 1 by default.
+	HalfOpenRequests int
+	// OnStateChange is called after every state change.
+	OnStateChange func(from, to State)
+	// Clock returns the current time, the system clock by default.
+	Clock Clock
+}
+
+// Counts are the requests and failures of the rolling window.
+type Counts struct {
+	Requests int
+	Failures int
+}
+
+// Breaker is a circuit breaker http.RoundTripper. It is safe for concurrent use.
+type Breaker struct {
+	transport http.RoundTripper
+	opts      Options
+	isFailure func(codes.StatusCode) bool
+
+	mu         sync.Mutex
+	state      State
+	generation uint64
+	window     window
+	openedAt   time.Time
+	probes     int
+	successes  int
+}
+
+// OpenError is returned for requests rejected by an open or half-open
+// breaker. It matches ErrOpen with errors.Is and replies 503 with codes.FromError.
+type OpenError struct {
+	State State
+	// RetryAfter is the time left before probing, 0 when half-open.
+	RetryAfter time.Duration
+}
+
+// ErrOpen is matched by every OpenError.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// Breaker Funcs
+// --------------------------------------------------------------------
+
+// New returns a Breaker sending requests with transport,
+// http.DefaultTransport when nil.
+func New(transport http.RoundTripper, opts Options) *Breaker {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if opts.Window <= 0 {
+		opts.Window = 10 * time.Second
+	}
+	if opts.Buckets <= 0 {
+		opts.Buckets = 10
+	}
+	if opts.MinRequests <= 0 {
+		opts.MinRequests = 20
+	}
+	if opts.FailureRatio <= 0 {
+		opts.FailureRatio = 0.5
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = 30 * time.Second
+	}
+	if opts.HalfOpenRequests <= 0 {
+		opts.HalfOpenRequests = 1
+	}
+	if opts.Clock == nil {
+		opts.Clock = systemClock{}
+	}
+
+	b := &Breaker{
+		transport: transport,
+		opts:      opts,
+		isFailure: failureFunc(opts.FailureClasses, opts.FailureCodes),
+	}
+	b.window = newWindow(opts.Window, opts.Buckets, opts.Clock.Now())
+	return b
+}
+
+// RoundTrip sends the request unless the breaker rejects it with an *OpenError.
+func (b *Breaker) RoundTrip(req *http.Request) (*http.Response, error) {
+	generation, err := b.allow()
+	if err != nil {
+		// RoundTrippers close the request body, even on errors.
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	resp, err := b.transport.RoundTrip(req)
+	if err != nil {
+		canceled := errors.Is(err, context.Canceled)
+		b.record(generation, !canceled, canceled)
+		return nil, err
+	}
+	b.record(generation, b.isFailure(codes.StatusCode(resp.StatusCode)), false)
+	return resp, nil
+}
+
+// State returns the current state. An open breaker whose timeout passed is half-open.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	changes := b.refresh(b.opts.Clock.Now())
+	state := b.state
+	b.mu.Unlock()
+	b.notify(changes)
+	return state
+}
+
+// Counts returns the requests and failures of the rolling window.
+func (b *Breaker) Counts() Counts {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.window.advance(b.opts.Clock.Now())
+	return b.window.counts()
+}
+
+// Reset closes the breaker and clears its window.
+func (b *Breaker) Reset() {
+	b.mu.Lock()
+	changes := b.setState(Closed, b.opts.Clock.Now())
+	b.mu.Unlock()
+	b.notify(changes)
+}
+
+// allow returns the generation of an allowed request, or an *OpenError.
+func (b *Breaker) allow() (uint64, error) {
+	b.mu.Lock()
+	now := b.opts.Clock.Now()
+	changes := b.refresh(now)
+
+	var err error
+	switch b.state {
+	case Open:
+		err = &OpenError{State: Open, RetryAfter: b.openedAt.Add(b.opts.OpenTimeout).Sub(now)}
+	case HalfOpen:
+		if b.probes >= b.opts.HalfOpenRequests {
+			err = &OpenError{State: HalfOpen}
+		} else {
+			b.probes++
+		}
+	}
+	generation := b.generation
+	b.mu.Unlock()
+
+	b.notify(changes)
+	return generation, err
+}
+
+// record counts the result of a request allowed in generation. Ignored
+// results, such as canceled requests, only release half-open probes.
+func (b *Breaker) record(generation uint64, failure, ignored bool) {
+	b.mu.Lock()
+	now := b.opts.Clock.Now()
+	var changes [][2]State
+	if generation == b.generation {
+		switch b.state {
+		case Closed:
+			if !ignored {
+				b.window.add(now, failure)
+				c := b.window.counts()
+				if c.Requests >= b.opts.MinRequests && float64(c.Failures) >= b.opts.FailureRatio*float64(c.Requests) {
+					changes = b.setState(Open, now)
+				}
+			}
+		case HalfOpen:
+			switch {
+			case ignored:
+				b.probes--
+			case failure:
+				changes = b.setState(Open, now)
+			default:
+				b.successes++
+				if b.successes >= b.opts.HalfOpenRequests {
+					changes = b.setState(Closed, now)
+				}
+			}
+		}
+	}
+	b.mu.Unlock()
+	b.notify(changes)
+}
+
+// refresh moves an open breaker to half-open once its timeout passed. b.mu must be held.
+func (b *Breaker) refresh(now time.Time) [][2]State {
+	if b.state == Open && !now.Before(b.openedAt.Add(b.opts.OpenTimeout)) {
+		return b.setState(HalfOpen, now)
+	}
+	return nil
+}
+
+// setState changes the state and starts a new generation. b.mu must be held.
+func (b *Breaker) setState(state State, now time.Time) [][2]State {
+	from := b.state
+	b.state = state
+	b.generation++
+	b.probes, b.successes = 0, 0
+	switch state {
+	case Open:
+		b.openedAt = now
+	case Closed:
+		b.window = newWindow(b.opts.Window, b.opts.Buckets, now)
+	}
+	if from == state {
+		return nil
+	}
+	return [][2]State{{from, state}}
+}
+
+// notify calls OnStateChange for changes, outside of b.mu.
+func (b *Breaker) notify(changes [][2]State) {
+	if b.opts.OnStateChange == nil {
+		return
+	}
+	for _, c := range changes {
+		b.opts.OnStateChange(c[0], c[1])
+	}
+}
+
+// failureFunc returns the failure classification of the options.
+func failureFunc(classes []codes.Class, failureCodes []codes.StatusCode) func(codes.StatusCode) bool {
+	if len(classes) == 0 && len(failureCodes) == 0 {
+		return func(code codes.StatusCode) bool {
+			return codes.IsServerError(code) || code == codes.TooManyRequests
+		}
+	}
+
+	classes = append([]codes.Class(nil), classes...)
+	set := make(map[codes.StatusCode]bool, len(failureCodes))
+	for _, code := range failureCodes {
+		set[code] = true
+	}
+	return func(code codes.StatusCode) bool {
+		for _, c := range classes {
+			if c.Contains(code) {
+				return true
+			}
+		}
+		return set[code]
+	}
+}
+
+// State Funcs
+// --------------------------------------------------------------------
+
+// String returns "closed", "open" or "half-open".
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Error returns the state and the time left before probing.
+func (e *OpenError) Error() string {
+	if e.State == HalfOpen {
+		return ErrOpen.Error() + ": half-open, probe in progress"
+	}
+	return fmt.Sprintf("%s: retry in %s", ErrOpen, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Is matches ErrOpen.
+func (e *OpenError) Is(target error) bool {
+	return target == ErrOpen
+}
+
+// StatusCode returns 503 Service Unavailable.
+func (e *OpenError) StatusCode() codes.StatusCode {
+	return codes.ServiceUnavailable
+}
@@ -0,0 +1,63 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock Types
+// --------------------------------------------------------------------
+
+// Clock returns the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock of the system.
+type systemClock struct{}
+
+// FakeClock is a Clock moved by hand, for tests. It is safe for concurrent use.
+//
+// Example:
+//
+//	clock := breaker.NewFakeClock(time.Now())
+//	b := breaker.New(transport, breaker.Options{Clock: clock})
+//	clock.Advance(30 * time.Second) // Open timeout passed
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// Clock Funcs
+// --------------------------------------------------------------------
+
+// Now returns time.Now.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
@@ -0,0 +1,70 @@
+package breaker
+
+import "time"
+
+// Window Types
+// --------------------------------------------------------------------
+
+// bucket counts the requests of a slice of the window.
+type bucket struct {
+	requests int
+	failures int
+}
+
+// window is a rolling window of buckets. The current bucket starts at start.
+type window struct {
+	width   time.Duration
+	buckets []bucket
+	current int
+	start   time.Time
+}
+
+// Window Funcs
+// --------------------------------------------------------------------
+
+// newWindow returns an empty window of size split in n buckets.
+func newWindow(size time.Duration, n int, now time.Time) window {
+	width := size / time.Duration(n)
+	if width <= 0 {
+		width = 1
+	}
+	return window{width: width, buckets: make([]bucket, n), start: now}
+}
+
+// advance drops the buckets older than the window at now.
+func (w *window) advance(now time.Time) {
+	if now.Before(w.start.Add(w.width)) {
+		return
+	}
+	steps := int(now.Sub(w.start) / w.width)
+	if steps >= len(w.buckets) {
+		for i := range w.buckets {
+			w.buckets[i] = bucket{}
+		}
+	} else {
+		for i := 0; i < steps; i++ {
+			w.current = (w.current + 1) % len(w.buckets)
+			w.buckets[w.current] = bucket{}
+		}
+	}
+	w.start = w.start.Add(time.Duration(steps) * w.width)
+}
+
+// add counts a request at now.
+func (w *window) add(now time.Time, failure bool) {
+	w.advance(now)
+	w.buckets[w.current].requests++
+	if failure {
+		w.buckets[w.current].failures++
+	}
+}
+
+// counts returns the totals of the window.
+func (w *window) counts() Counts {
+	var c Counts
+	for _, b := range w.buckets {
+		c.Requests += b.requests
+		c.Failures += b.failures
+	}
+	return c
+}
@@ -0,0 +1,637 @@
+package codes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog Types
+// --------------------------------------------------------------------
+
+// Catalog is a set of custom status codes and methods loaded from a JSON or
+// YAML file, so products can manage internal codes without recompiling.
+//
+// Example file:
+//
+//	name: payments
+//	status_codes:
+//	  - code: 701
+//	    phrase: Payment Declined
+//	    description: Payment provider declined the transaction
+//	    phrases:
+//	      pt-BR: Pagamento Recusado
+//	    metadata:
+//	      owner: payments-team
+//	methods:
+//	  - name: PURGE
+//	    description: Remove a cached resource
+//	    idempotent: true
+type Catalog struct {
+	Name        string          `yaml:"name" json:"name"`
+	StatusCodes []CatalogStatus `yaml:"status_codes" json:"status_codes"`
+	Methods     []CatalogMethod `yaml:"methods" json:"methods"`
+}
+
+// CatalogStatus is a custom status code of a Catalog.
+type CatalogStatus struct {
+	Code        StatusCode        `yaml:"code" json:"code"`
+	Phrase      string            `yaml:"phrase" json:"phrase"`
+	Description Description       `yaml:"description" json:"description"`
+	Phrases     map[string]string `yaml:"phrases" json:"phrases,omitempty"`
+	Metadata    map[string]string `yaml:"metadata" json:"metadata,omitempty"`
+}
+
+// CatalogMethod is a custom method of a Catalog.
+type CatalogMethod struct {
+	Name        Method            `yaml:"name" json:"name"`
+	Description Description       `yaml:"description" json:"description"`
+	Safe        bool              `yaml:"safe" json:"safe"`
+	Idempotent  bool              `yaml:"idempotent" json:"idempotent"`
+	Metadata    map[string]string `yaml:"metadata" json:"metadata,omitempty"`
+}
+
+// CatalogError reports an invalid catalog with the position of the problem.
+type CatalogError struct {
+	File string
+	Line int
+	Err  error
+}
+
+// ErrInvalidCatalog is wrapped by every CatalogError.
+var ErrInvalidCatalog = errors.New("invalid catalog")
+
+// Error returns the error prefixed with its position, "file:line: message".
+func (e *CatalogError) Error() string {
+	file := e.File
+	if file == "" {
+		file = "catalog"
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %v", file, e.Line, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", file, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CatalogError) Unwrap() error {
+	return e.Err
+}
+
+// Catalog Registry
+// --------------------------------------------------------------------
+
+// statusMetadataMap holds the metadata of status codes loaded from catalogs.
+var statusMetadataMap = map[StatusCode]map[string]string{}
+
+// methodMetadataMap holds the metadata of methods loaded from catalogs.
+var methodMetadataMap = map[Method]map[string]string{}
+
+// GetStatusMetadata returns a copy of the metadata of a status code, or nil if none.
+func GetStatusMetadata(code StatusCode) map[string]string {
+	defer rlock()()
+	return copyMetadata(statusMetadataMap[code])
+}
+
+// GetMethodMetadata returns a copy of the metadata of a method, or nil if none.
+func GetMethodMetadata(method Method) map[string]string {
+	defer rlock()()
+	return copyMetadata(methodMetadataMap[method])
+}
+
+// GetLocalizedStatusPhrase returns the reason phrase of a status code in the
+// given language tag (for example "pt-BR"), without fallbacks.
+// See GetStatusPhraseLocale for lookups with fallbacks.
+func GetLocalizedStatusPhrase(code StatusCode, lang string) (string, bool) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", false
+	}
+
+	defer rlock()()
+	phrase, exists := localizedPhraseMap[tag][code]
+	return phrase, exists
+}
+
+// Catalog Funcs
+// --------------------------------------------------------------------
+
+// ParseCatalog reads and validates a JSON or YAML catalog without applying it.
+// Validation errors are *CatalogError values holding the line of the problem.
+func ParseCatalog(r io.Reader) (*Catalog, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, &CatalogError{Line: yamlErrorLine(err), Err: fmt.Errorf("%w: %v", ErrInvalidCatalog, err)}
+	}
+
+	c := &Catalog{}
+	if len(root.Content) == 0 {
+		return c, nil
+	}
+
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, catalogErr(doc, "expected a mapping at the top level")
+	}
+
+	for i := 0; i < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		switch key.Value {
+		case "name":
+			if err := value.Decode(&c.Name); err != nil {
+				return nil, catalogErr(value, "name must be a string")
+			}
+		case "status_codes":
+			if err := parseCatalogStatuses(c, value); err != nil {
+				return nil, err
+			}
+		case "methods":
+			if err := parseCatalogMethods(c, value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, catalogErr(key, "unknown field %q", key.Value)
+		}
+	}
+	return c, nil
+}
+
+// LoadCatalog reads, validates and applies a JSON or YAML catalog.
+// Nothing is applied if the catalog is invalid.
+func LoadCatalog(r io.Reader) (*Catalog, error) {
+	c, err := ParseCatalog(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Apply(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LoadCatalogFile reads, validates and applies the catalog at path.
+// Errors are prefixed with the path.
+func LoadCatalogFile(path string) (*Catalog, error) {
+	c, err := ParseCatalogFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Apply(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ParseCatalogFile reads and validates the catalog at path without applying it.
+func ParseCatalogFile(path string) (*Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c, err := ParseCatalog(f)
+	var cerr *CatalogError
+	if errors.As(err, &cerr) {
+		cerr.File = path
+	}
+	return c, err
+}
+
+// Apply registers every entry of the catalog in a single step, replacing
+// entries with the same code or name. Readers never see a partially
+// applied catalog. Catalogs built in code are validated like parsed ones,
+// so built-in codes and methods cannot be replaced. Returns ErrSealed
+// after Seal.
+func (c *Catalog) Apply() error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	if sealed.Load() {
+		mu.Unlock()
+		return sealedError()
+	}
+	muts := swapCatalogsLocked(nil, c)
+	notify := recordMutations(muts...)
+	mu.Unlock()
+
+	notify()
+	return nil
+}
+
+// applyLocked registers the catalog entries. mu must be held.
+func (c *Catalog) applyLocked() {
+	for _, s := range c.StatusCodes {
+		StatusDescriptionMap[s.Code] = s.Description
+		StatusPhraseMap[s.Code] = s.Phrase
+		setMetadata(statusMetadataMap, s.Code, s.Metadata)
+		for lang, phrase := range s.Phrases {
+			setLocalized(localizedPhraseMap, language.Make(lang), s.Code, phrase)
+		}
+	}
+
+	for _, m := range c.Methods {
+		MethodDescriptionMap[m.Name] = m.Description
+		setFlag(safeMethods, m.Name, m.Safe)
+		setFlag(idempotentMethods, m.Name, m.Idempotent || m.Safe)
+		setMetadata(methodMetadataMap, m.Name, m.Metadata)
+	}
+}
+
+// Remove unregisters every entry of the catalog in a single step.
+// Catalogs holding built-in entries are rejected, see Apply. Returns
+// ErrSealed after Seal.
+func (c *Catalog) Remove() error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	if sealed.Load() {
+		mu.Unlock()
+		return sealedError()
+	}
+	muts := swapCatalogsLocked(c, nil)
+	notify := recordMutations(muts...)
+	mu.Unlock()
+
+	notify()
+	return nil
+}
+
+// swapCatalogsLocked replaces the entries of prev with those of next, either
+// may be nil, and returns the resulting mutations. mu must be held.
+func swapCatalogsLocked(prev, next *Catalog) []Mutation {
+	type entry struct {
+		desc    Description
+		existed bool
+	}
+	codesBefore := map[StatusCode]entry{}
+	methodsBefore := map[Method]entry{}
+	for _, c := range []*Catalog{prev, next} {
+		if c == nil {
+			continue
+		}
+		for _, s := range c.StatusCodes {
+			desc, existed := StatusDescriptionMap[s.Code]
+			codesBefore[s.Code] = entry{desc, existed}
+		}
+		for _, m := range c.Methods {
+			desc, existed := MethodDescriptionMap[m.Name]
+			methodsBefore[m.Name] = entry{desc, existed}
+		}
+	}
+
+	if prev != nil {
+		prev.removeLocked()
+	}
+	if next != nil {
+		next.applyLocked()
+	}
+
+	var muts []Mutation
+	codeKeys := make([]StatusCode, 0, len(codesBefore))
+	for code := range codesBefore {
+		codeKeys = append(codeKeys, code)
+	}
+	sort.Slice(codeKeys, func(i, j int) bool { return codeKeys[i] < codeKeys[j] })
+	for _, code := range codeKeys {
+		before := codesBefore[code]
+		desc, exists := StatusDescriptionMap[code]
+		switch {
+		case exists && !(before.existed && before.desc == desc):
+			muts = append(muts, Mutation{Kind: MutationRegister, Code: code, Old: before.desc, New: desc, Existed: before.existed})
+		case !exists && before.existed:
+			muts = append(muts, Mutation{Kind: MutationDelete, Code: code, Old: before.desc, Existed: true})
+		}
+	}
+
+	methods := make([]Method, 0, len(methodsBefore))
+	for method := range methodsBefore {
+		methods = append(methods, method)
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i] < methods[j] })
+	for _, method := range methods {
+		before := methodsBefore[method]
+		desc, exists := MethodDescriptionMap[method]
+		switch {
+		case exists && !(before.existed && before.desc == desc):
+			muts = append(muts, Mutation{Kind: MutationRegister, Method: method, Old: before.desc, New: desc, Existed: before.existed})
+		case !exists && before.existed:
+			muts = append(muts, Mutation{Kind: MutationDelete, Method: method, Old: before.desc, Existed: true})
+		}
+	}
+	return muts
+}
+
+// removeLocked unregisters the catalog entries. mu must be held.
+func (c *Catalog) removeLocked() {
+	for _, s := range c.StatusCodes {
+		delete(StatusDescriptionMap, s.Code)
+		delete(StatusPhraseMap, s.Code)
+		delete(statusMetadataMap, s.Code)
+		for lang := range s.Phrases {
+			delete(localizedPhraseMap[language.Make(lang)], s.Code)
+		}
+	}
+
+	for _, m := range c.Methods {
+		delete(MethodDescriptionMap, m.Name)
+		delete(safeMethods, m.Name)
+		delete(idempotentMethods, m.Name)
+		delete(methodMetadataMap, m.Name)
+	}
+}
+
+// Parsing
+// --------------------------------------------------------------------
+
+// parseCatalogStatuses decodes and validates the status_codes sequence.
+func parseCatalogStatuses(c *Catalog, node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return catalogErr(node, "status_codes must be a list")
+	}
+
+	seen := map[StatusCode]int{}
+	for _, item := range node.Content {
+		if err := checkFields(item, "code", "phrase", "description", "phrases", "metadata"); err != nil {
+			return err
+		}
+
+		var raw struct {
+			Code        *int              `yaml:"code"`
+			Phrase      string            `yaml:"phrase"`
+			Description string            `yaml:"description"`
+			Phrases     map[string]string `yaml:"phrases"`
+			Metadata    map[string]string `yaml:"metadata"`
+		}
+		if err := item.Decode(&raw); err != nil {
+			return catalogErr(item, "%v", err)
+		}
+
+		if raw.Code == nil {
+			return catalogErr(item, "status code is required")
+		}
+		if msg := checkCatalogCode(*raw.Code); msg != "" {
+			return catalogErr(fieldNode(item, "code"), "%s", msg)
+		}
+		if strings.TrimSpace(raw.Description) == "" {
+			return catalogErr(item, "status code %d has no description", *raw.Code)
+		}
+
+		code := StatusCode(*raw.Code)
+		if line, dup := seen[code]; dup {
+			return catalogErr(fieldNode(item, "code"), "status code %d already defined on line %d", code, line)
+		}
+		seen[code] = fieldNode(item, "code").Line
+
+		phrases, err := parseCatalogPhrases(fieldNode(item, "phrases"), raw.Phrases)
+		if err != nil {
+			return err
+		}
+
+		phrase := strings.TrimSpace(raw.Phrase)
+		if phrase == "" {
+			phrase = strings.TrimSpace(raw.Description)
+		}
+		c.StatusCodes = append(c.StatusCodes, CatalogStatus{
+			Code:        code,
+			Phrase:      phrase,
+			Description: Description(strings.TrimSpace(raw.Description)),
+			Phrases:     phrases,
+			Metadata:    raw.Metadata,
+		})
+	}
+	return nil
+}
+
+// parseCatalogPhrases checks the language tags of localized phrases and
+// returns the phrases keyed by canonical tags.
+func parseCatalogPhrases(node *yaml.Node, phrases map[string]string) (map[string]string, error) {
+	if len(phrases) == 0 {
+		return nil, nil
+	}
+
+	out := make(map[string]string, len(phrases))
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		tag, err := language.Parse(key.Value)
+		if err != nil {
+			return nil, catalogErr(key, "invalid language tag %q", key.Value)
+		}
+		if strings.TrimSpace(phrases[key.Value]) == "" {
+			return nil, catalogErr(key, "empty phrase for %s", tag)
+		}
+		out[tag.String()] = strings.TrimSpace(phrases[key.Value])
+	}
+	return out, nil
+}
+
+// parseCatalogMethods decodes and validates the methods sequence.
+func parseCatalogMethods(c *Catalog, node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return catalogErr(node, "methods must be a list")
+	}
+
+	seen := map[Method]int{}
+	for _, item := range node.Content {
+		if err := checkFields(item, "name", "description", "safe", "idempotent", "metadata"); err != nil {
+			return err
+		}
+
+		var raw struct {
+			Name        string            `yaml:"name"`
+			Description string            `yaml:"description"`
+			Safe        bool              `yaml:"safe"`
+			Idempotent  bool              `yaml:"idempotent"`
+			Metadata    map[string]string `yaml:"metadata"`
+		}
+		if err := item.Decode(&raw); err != nil {
+			return catalogErr(item, "%v", err)
+		}
+
+		method := Method(raw.Name)
+		if raw.Name == "" {
+			return catalogErr(item, "method name is required")
+		}
+		if msg := checkCatalogMethod(method); msg != "" {
+			return catalogErr(fieldNode(item, "name"), "%s", msg)
+		}
+		if strings.TrimSpace(raw.Description) == "" {
+			return catalogErr(item, "method %s has no description", raw.Name)
+		}
+
+		if line, dup := seen[method]; dup {
+			return catalogErr(fieldNode(item, "name"), "method %s already defined on line %d", method, line)
+		}
+		seen[method] = fieldNode(item, "name").Line
+
+		c.Methods = append(c.Methods, CatalogMethod{
+			Name:        method,
+			Description: Description(strings.TrimSpace(raw.Description)),
+			Safe:        raw.Safe,
+			Idempotent:  raw.Idempotent,
+			Metadata:    raw.Metadata,
+		})
+	}
+	return nil
+}
+
+// validate checks the entries of a catalog built in code with the rules of
+// ParseCatalog, except for duplicates.
+func (c *Catalog) validate() error {
+	for _, s := range c.StatusCodes {
+		if msg := checkCatalogCode(int(s.Code)); msg != "" {
+			return catalogErrorf("%s", msg)
+		}
+		if strings.TrimSpace(string(s.Description)) == "" {
+			return catalogErrorf("status code %d has no description", int(s.Code))
+		}
+	}
+	for _, m := range c.Methods {
+		if msg := checkCatalogMethod(m.Name); msg != "" {
+			return catalogErrorf("%s", msg)
+		}
+		if strings.TrimSpace(string(m.Description)) == "" {
+			return catalogErrorf("method %s has no description", m.Name)
+		}
+	}
+	return nil
+}
+
+// checkCatalogCode returns why a catalog cannot define code, or "".
+func checkCatalogCode(code int) string {
+	switch {
+	case code >= 100 && code <= 600:
+		return fmt.Sprintf("status code %d is in the built-in range (100-600)", code)
+	case code < 100 || code > 999:
+		return fmt.Sprintf("status code %d must have three digits", code)
+	}
+	return ""
+}
+
+// checkCatalogMethod returns why a catalog cannot define method, or "".
+func checkCatalogMethod(method Method) string {
+	switch {
+	case !isToken(string(method)):
+		return fmt.Sprintf("method %q is not a valid token", string(method))
+	case isStandardMethod(method):
+		return fmt.Sprintf("method %s is built-in", method)
+	case ianaMethods[method]:
+		return fmt.Sprintf("method %s is in the IANA registry", method)
+	}
+	return ""
+}
+
+// checkFields checks node is a mapping holding only the allowed keys.
+func checkFields(node *yaml.Node, allowed ...string) error {
+	if node.Kind != yaml.MappingNode {
+		return catalogErr(node, "expected a mapping")
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		key := node.Content[i]
+		known := false
+		for _, a := range allowed {
+			if key.Value == a {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return catalogErr(key, "unknown field %q", key.Value)
+		}
+	}
+	return nil
+}
+
+// fieldNode returns the value node of a mapping key, or the mapping itself.
+func fieldNode(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return node
+}
+
+// catalogErr builds a CatalogError at the line of node.
+func catalogErr(node *yaml.Node, format string, args ...interface{}) error {
+	err := catalogErrorf(format, args...)
+	err.Line = node.Line
+	return err
+}
+
+// catalogErrorf builds a CatalogError without a position.
+func catalogErrorf(format string, args ...interface{}) *CatalogError {
+	return &CatalogError{Err: fmt.Errorf("%w: %s", ErrInvalidCatalog, fmt.Sprintf(format, args...))}
+}
+
+// yamlErrorLine extracts the line from a yaml syntax error, or 0.
+func yamlErrorLine(err error) int {
+	var line int
+	if _, scanErr := fmt.Sscanf(err.Error(), "yaml: line %d:", &line); scanErr != nil {
+		return 0
+	}
+	return line
+}
+
+// Utils
+// --------------------------------------------------------------------
+
+// isStandardMethod checks if the method is one of the built-in methods.
+func isStandardMethod(method Method) bool {
+	return method == GET || method == POST || method == PUT || method == DELETE || method == PATCH || method == HEAD || method == OPTIONS || method == CONNECT || method == TRACE
+}
+
+// isToken checks if s is a valid HTTP token, as defined by RFC 9110, Section 5.6.2.
+func isToken(s string) bool {
+	for _, r := range s {
+		if r > 127 || r <= ' ' || strings.ContainsRune("\"(),/:;<=>?@[\\]{}", r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// setMetadata stores a copy of md under key, or removes the key if md is empty.
+func setMetadata[K comparable](m map[K]map[string]string, key K, md map[string]string) {
+	if len(md) == 0 {
+		delete(m, key)
+		return
+	}
+	m[key] = copyMetadata(md)
+}
+
+// setFlag sets or removes a boolean set entry.
+func setFlag(m map[Method]bool, key Method, value bool) {
+	if value {
+		m[key] = true
+		return
+	}
+	delete(m, key)
+}
+
+// copyMetadata returns a copy of md, or nil if it is empty.
+func copyMetadata(md map[string]string) map[string]string {
+	if len(md) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(md))
+	for k, v := range md {
+		out[k] = v
+	}
+	return out
+}
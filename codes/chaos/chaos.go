@@ -0,0 +1,470 @@
+// Package chaos provides a fault injection middleware replying with chosen
+// status codes or delaying requests, to exercise the failure paths of clients
+// in staging environments.
+//
+// Example:
+//
+//	injector, err := chaos.New(
+//	    chaos.Rule{Name: "orders-down", Path: "/api/orders*", Probability: 0.1, Status: codes.ServiceUnavailable, RetryAfter: 30 * time.Second},
+//	    chaos.Rule{Name: "slow-writes", Methods: []codes.Method{codes.POST}, Probability: 0.5, Delay: 2 * time.Second},
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	mux.Handle("/admin/chaos", injector.AdminHandler())
+//	http.ListenAndServe(":8080", injector.Middleware(mux))
+//
+// Injected errors are rendered with codes.DefaultErrorPage, so they carry the
+// phrases, descriptions and messages of the codes package.
+package chaos
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/internal/delay"
+)
+
+// Chaos Types
+// --------------------------------------------------------------------
+
+// Rule selects requests and the fault injected in them. Empty conditions
+// match every request.
+type Rule struct {
+	// Name identifies the rule in the X-Chaos-Rule response header.
+	Name string
+	// Path is an exact path, or a prefix ending with "*" such as "/api/*".
+	Path string
+	// Methods are the matched methods.
+	Methods []codes.Method
+	// Header is a request header that must be present, with HeaderValue
+	// as value when it is not empty.
+	Header      string
+	HeaderValue string
+	// Probability is the chance of a matching request to be faulted, from 0 to 1.
+	Probability float64
+	// Status is the status code replied with. When 0, requests are only delayed.
+	Status codes.StatusCode
+	// Delay is waited before replying or calling the next handler.
+	Delay time.Duration
+	// RetryAfter sets the Retry-After header of the injected response.
+	RetryAfter time.Duration
+}
+
+// ruleJSON is the JSON form of a Rule, with durations as strings such as "1.5s".
+type ruleJSON struct {
+	Name        string           `json:"name,omitempty"`
+	Path        string           `json:"path,omitempty"`
+	Methods     []codes.Method   `json:"methods,omitempty"`
+	Header      string           `json:"header,omitempty"`
+	HeaderValue string           `json:"header_value,omitempty"`
+	Probability float64          `json:"probability"`
+	Status      codes.StatusCode `json:"status,omitempty"`
+	Delay       string           `json:"delay,omitempty"`
+	RetryAfter  string           `json:"retry_after,omitempty"`
+}
+
+// State is the configuration of an Injector, as served by its admin handler.
+type State struct {
+	Enabled bool   `json:"enabled"`
+	Rules   []Rule `json:"rules"`
+}
+
+// Injector injects the faults of its rules. It is safe for concurrent use.
+type Injector struct {
+	mu      sync.RWMutex
+	enabled bool
+	rules   []Rule
+
+	randMu sync.Mutex
+	rand   *rand.Rand
+
+	// ErrorPage renders the injected responses, codes.DefaultErrorPage when nil.
+	ErrorPage *codes.ErrorPage
+}
+
+// ErrInvalidRule is returned for rules that cannot be applied.
+var ErrInvalidRule = errors.New("invalid chaos rule")
+
+// adminBodyLimit is the maximum size of an admin request body.
+const adminBodyLimit = 1 << 20
+
+// Injector Funcs
+// --------------------------------------------------------------------
+
+// New returns an enabled Injector with the given rules.
+func New(rules ...Rule) (*Injector, error) {
+	i := &Injector{enabled: true, rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	if err := i.SetRules(rules...); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// SetRules validates and replaces the rules.
+func (i *Injector) SetRules(rules ...Rule) error {
+	if err := validateRules(rules); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = copyRules(rules)
+	return nil
+}
+
+// SetState validates and replaces the rules and the enabled flag at once.
+func (i *Injector) SetState(state State) error {
+	if err := validateRules(state.Rules); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.enabled = state.Enabled
+	i.rules = copyRules(state.Rules)
+	return nil
+}
+
+// Rules returns a copy of the rules.
+func (i *Injector) Rules() []Rule {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return copyRules(i.rules)
+}
+
+// Enable starts injecting faults.
+func (i *Injector) Enable() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.enabled = true
+}
+
+// Disable stops injecting faults, keeping the rules.
+func (i *Injector) Disable() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.enabled = false
+}
+
+// Enabled checks if faults are injected.
+func (i *Injector) Enabled() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.enabled
+}
+
+// State returns the current configuration.
+func (i *Injector) State() State {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return State{Enabled: i.enabled, Rules: copyRules(i.rules)}
+}
+
+// Seed sets the seed of the probability draws, for reproducible runs.
+func (i *Injector) Seed(seed int64) {
+	i.randMu.Lock()
+	defer i.randMu.Unlock()
+	i.rand = rand.New(rand.NewSource(seed))
+}
+
+// Middleware injects the fault of the first matching rule drawn, if any,
+// before calling next.
+func (i *Injector) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rule, ok := i.match(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if rule.Delay > 0 && !delay.Sleep(r.Context(), rule.Delay) {
+			return
+		}
+		if rule.Status == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if rule.Name != "" {
+			w.Header().Set("X-Chaos-Rule", rule.Name)
+		}
+		if rule.RetryAfter > 0 {
+			seconds := (rule.RetryAfter + time.Second - 1) / time.Second
+			w.Header().Set("Retry-After", strconv.Itoa(int(seconds)))
+		}
+		i.errorPage().Render(w, r, rule.Status, nil)
+	})
+}
+
+// AdminHandler returns a handler reading and changing the State at runtime:
+//
+//   - GET replies with the state as JSON
+//   - PUT replaces the state with a JSON body
+//   - POST with "enabled" set to true or false in the query toggles injection
+//   - DELETE removes every rule
+//
+// Example:
+//
+//	curl -X PUT localhost:8080/admin/chaos -d '{"enabled":true,"rules":[{"path":"/api/*","probability":0.2,"status":503}]}'
+//	curl -X POST 'localhost:8080/admin/chaos?enabled=false'
+func (i *Injector) AdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut:
+			var state State
+			dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, adminBodyLimit))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&state); err != nil {
+				adminError(w, r, err)
+				return
+			}
+			if err := i.SetState(state); err != nil {
+				adminError(w, r, codes.NewHTTPError(codes.UnprocessableEntity, err.Error(), err))
+				return
+			}
+		case http.MethodPost:
+			enabled, err := strconv.ParseBool(r.URL.Query().Get("enabled"))
+			if err != nil {
+				adminError(w, r, codes.NewHTTPError(codes.BadRequest, `query parameter "enabled" must be true or false`, err))
+				return
+			}
+			if enabled {
+				i.Enable()
+			} else {
+				i.Disable()
+			}
+		case http.MethodDelete:
+			_ = i.SetRules()
+		default:
+			w.Header().Set("Allow", "GET, HEAD, PUT, POST, DELETE")
+			adminError(w, r, codes.NewHTTPError(codes.MethodNotAllowed, "", nil))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if r.Method != http.MethodHead {
+			json.NewEncoder(w).Encode(i.State())
+		}
+	})
+}
+
+// match returns the first rule matching r and drawn by its probability.
+func (i *Injector) match(r *http.Request) (Rule, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if !i.enabled {
+		return Rule{}, false
+	}
+	for _, rule := range i.rules {
+		if rule.Matches(r) && i.draw(rule.Probability) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// draw returns true with probability p.
+func (i *Injector) draw(p float64) bool {
+	if p >= 1 {
+		return true
+	}
+	i.randMu.Lock()
+	defer i.randMu.Unlock()
+	return i.rand.Float64() < p
+}
+
+// errorPage returns the renderer of the injected responses.
+func (i *Injector) errorPage() *codes.ErrorPage {
+	if i.ErrorPage != nil {
+		return i.ErrorPage
+	}
+	return codes.DefaultErrorPage
+}
+
+// adminError replies to an invalid admin request with problem details,
+// whatever the Accept header, since the admin API only speaks JSON.
+func adminError(w http.ResponseWriter, r *http.Request, err error) {
+	code := codes.FromError(err)
+	problem, ok := codes.ProblemOf(err)
+	if !ok {
+		if code == codes.InternalServerError {
+			code = codes.BadRequest
+		}
+		problem = codes.NewProblem(code, err.Error())
+	}
+	if problem.Detail == "" {
+		problem.Detail = codes.GetStatusInfo(code)
+	}
+	problem.Status = code
+	problem.Instance = r.URL.Path
+
+	w.Header().Set("Content-Type", codes.ProblemContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(int(code))
+	json.NewEncoder(w).Encode(problem)
+}
+
+// Rule Funcs
+// --------------------------------------------------------------------
+
+// Validate checks the probability, status code, methods and path of the rule.
+func (r Rule) Validate() error {
+	switch {
+	case r.Probability < 0 || r.Probability > 1:
+		return fmt.Errorf("%w: probability %v is not between 0 and 1", ErrInvalidRule, r.Probability)
+	case r.Status == 0 && r.Delay <= 0:
+		return fmt.Errorf("%w: no status code or delay", ErrInvalidRule)
+	case r.Status != 0 && (r.Status < 200 || r.Status > 599):
+		return fmt.Errorf("%w: status code %d cannot be injected", ErrInvalidRule, int(r.Status))
+	case r.Delay < 0 || r.RetryAfter < 0:
+		return fmt.Errorf("%w: negative duration", ErrInvalidRule)
+	case r.Path != "" && !strings.HasPrefix(r.Path, "/"):
+		return fmt.Errorf("%w: path %q does not start with /", ErrInvalidRule, r.Path)
+	case strings.Contains(strings.TrimSuffix(r.Path, "*"), "*"):
+		return fmt.Errorf("%w: path %q has a * before its end", ErrInvalidRule, r.Path)
+	}
+	for _, m := range r.Methods {
+		if err := codes.ValidateMethod(m); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+	return nil
+}
+
+// Matches checks if the path, method and header conditions of the rule match req.
+func (r Rule) Matches(req *http.Request) bool {
+	if r.Path != "" {
+		if prefix, found := strings.CutSuffix(r.Path, "*"); found {
+			if !strings.HasPrefix(req.URL.Path, prefix) {
+				return false
+			}
+		} else if req.URL.Path != r.Path {
+			return false
+		}
+	}
+
+	if len(r.Methods) > 0 {
+		found := false
+		for _, m := range r.Methods {
+			found = found || string(m) == req.Method
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if r.Header != "" {
+		values, exists := req.Header[http.CanonicalHeaderKey(r.Header)]
+		if !exists || (r.HeaderValue != "" && !contains(values, r.HeaderValue)) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON encodes the rule with durations as strings such as "1.5s".
+func (r Rule) MarshalJSON() ([]byte, error) {
+	j := ruleJSON{
+		Name:        r.Name,
+		Path:        r.Path,
+		Methods:     r.Methods,
+		Header:      r.Header,
+		HeaderValue: r.HeaderValue,
+		Probability: r.Probability,
+		Status:      r.Status,
+	}
+	if r.Delay != 0 {
+		j.Delay = r.Delay.String()
+	}
+	if r.RetryAfter != 0 {
+		j.RetryAfter = r.RetryAfter.String()
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes a rule with durations as strings such as "1.5s".
+// Unknown fields are rejected, so a misspelled field cannot silently widen a rule.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	var j ruleJSON
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&j); err != nil {
+		return err
+	}
+
+	rule := Rule{
+		Name:        j.Name,
+		Path:        j.Path,
+		Methods:     j.Methods,
+		Header:      j.Header,
+		HeaderValue: j.HeaderValue,
+		Probability: j.Probability,
+		Status:      j.Status,
+	}
+	var err error
+	if rule.Delay, err = parseDuration(j.Delay); err != nil {
+		return err
+	}
+	if rule.RetryAfter, err = parseDuration(j.RetryAfter); err != nil {
+		return err
+	}
+	*r = rule
+	return nil
+}
+
+// Utils
+// --------------------------------------------------------------------
+
+// validateRules validates rules, naming the first invalid one.
+func validateRules(rules []Rule) error {
+	for n, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rule %d: %w", n, err)
+		}
+	}
+	return nil
+}
+
+// parseDuration parses an optional duration.
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+	return d, nil
+}
+
+// copyRules returns a deep copy of rules.
+func copyRules(rules []Rule) []Rule {
+	out := make([]Rule, len(rules))
+	for n, rule := range rules {
+		rule.Methods = append([]codes.Method(nil), rule.Methods...)
+		out[n] = rule
+	}
+	return out
+}
+
+// contains checks if values holds v.
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package codes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Class Types
+// --------------------------------------------------------------------
+
+// Class represents the class of an HTTP status code, given by its first digit.
+type Class int
+
+// Status Code Classes
+const (
+	UnknownClass  Class = 0
+	Informational Class = 1
+	Success       Class = 2
+	Redirection   Class = 3
+	ClientError   Class = 4
+	ServerError   Class = 5
+)
+
+// classNames maps classes to their names, as used by RFC 9110.
+var classNames = map[Class]string{
+	Informational: "Informational",
+	Success:       "Successful",
+	Redirection:   "Redirection",
+	ClientError:   "Client Error",
+	ServerError:   "Server Error",
+}
+
+// ErrUnknownClass is returned when a status class cannot be parsed.
+var ErrUnknownClass = errors.New("unknown status class")
+
+// Class Funcs
+// --------------------------------------------------------------------
+
+// ClassOf returns the class of the status code, or UnknownClass if the code
+// is not a valid HTTP status code.
+func ClassOf(code StatusCode) Class {
+	if !IsValidStatusCode(code) {
+		return UnknownClass
+	}
+	return Class(code / 100)
+}
+
+// Class returns the class of the status code.
+func (sc StatusCode) Class() Class {
+	return ClassOf(sc)
+}
+
+// Contains checks if the status code belongs to the class.
+func (c Class) Contains(code StatusCode) bool {
+	return c != UnknownClass && ClassOf(code) == c
+}
+
+// StatusCodes returns the registered status codes of the class, sorted by code.
+//
+// Example:
+//
+//	for _, code := range codes.ClientError.StatusCodes() {
+//	    fmt.Println(code)
+//	}
+func (c Class) StatusCodes() []StatusCode {
+	unlock := rlock()
+	var out []StatusCode
+	for code := range StatusDescriptionMap {
+		if c.Contains(code) {
+			out = append(out, code)
+		}
+	}
+	unlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// Name returns the name of the class, for example "Client Error".
+func (c Class) Name() string {
+	if name, exists := classNames[c]; exists {
+		return name
+	}
+	return "Unknown Class"
+}
+
+// String returns the short form of the class, for example "4xx".
+func (c Class) String() string {
+	if c < Informational || c > ServerError {
+		return "unknown"
+	}
+	return string(rune('0'+c)) + "xx"
+}
+
+// ParseClass parses a class from its short form ("4xx", "4XX", "4") or its
+// name ("Client Error", case-insensitive).
+func ParseClass(s string) (Class, error) {
+	s = strings.TrimSpace(s)
+
+	for c := Informational; c <= ServerError; c++ {
+		if strings.EqualFold(s, c.String()) || s == c.String()[:1] || strings.EqualFold(s, c.Name()) {
+			return c, nil
+		}
+	}
+	return UnknownClass, fmt.Errorf("%w: %q", ErrUnknownClass, s)
+}
+
+// MarshalText encodes the class in its short form, for example "4xx".
+func (c Class) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a class, see ParseClass.
+func (c *Class) UnmarshalText(text []byte) error {
+	class, err := ParseClass(string(text))
+	if err != nil {
+		return err
+	}
+	*c = class
+	return nil
+}
@@ -28,8 +28,11 @@
 // for HTTP operations while maintaining compatibility with standard Go HTTP libraries.
 package codes
 
+//go:generate go run ../internal/gen/gencodes
+
 import (
 	"fmt"
+	"os"
 	"strings"
 	"sync"
 )
@@ -51,264 +54,63 @@ type Method string
 // Description represents a human-readable description of an HTTP status code.
 type Description string
 
-// Status Codes Constants
+// Status Code Registration
 // --------------------------------------------------------------------
 
-// HTTP Status Codes
-const (
-
-	// Informational 1xx
-
-	Continue           StatusCode = 100
-	SwitchingProtocols StatusCode = 101
-	Processing         StatusCode = 102
-
-	// Success 2xx
-
-	OK                   StatusCode = 200
-	Created              StatusCode = 201
-	Accepted             StatusCode = 202
-	NonAuthoritativeInfo StatusCode = 203
-	NoContent            StatusCode = 204
-	ResetContent         StatusCode = 205
-	PartialContent       StatusCode = 206
-
-	// Redirection 3xx
-
-	MultipleChoices   StatusCode = 300
-	MovedPermanently  StatusCode = 301
-	Found             StatusCode = 302
-	SeeOther          StatusCode = 303
-	NotModified       StatusCode = 304
-	UseProxy          StatusCode = 305
-	TemporaryRedirect StatusCode = 307
-	PermanentRedirect StatusCode = 308
-
-	// Bad Request 4xx
-
-	BadRequest                  StatusCode = 400
-	Unauthorized                StatusCode = 401
-	PaymentRequired             StatusCode = 402
-	Forbidden                   StatusCode = 403
-	NotFound                    StatusCode = 404
-	MethodNotAllowed            StatusCode = 405
-	NotAcceptable               StatusCode = 406
-	ProxyAuthRequired           StatusCode = 407
-	RequestTimeout              StatusCode = 408
-	Conflict                    StatusCode = 409
-	Gone                        StatusCode = 410
-	LengthRequired              StatusCode = 411
-	PreconditionFailed          StatusCode = 412
-	PayloadTooLarge             StatusCode = 413
-	URITooLong                  StatusCode = 414
-	UnsupportedMediaType        StatusCode = 415
-	RangeNotSatisfiable         StatusCode = 416
-	ExpectationFailed           StatusCode = 417
-	Teapot                      StatusCode = 418
-	UnprocessableEntity         StatusCode = 422
-	TooEarly                    StatusCode = 425
-	UpgradeRequired             StatusCode = 426
-	PreconditionRequired        StatusCode = 428
-	TooManyRequests             StatusCode = 429
-	RequestHeaderFieldsTooLarge StatusCode = 431
-	UnavailableForLegalReasons  StatusCode = 451
-
-	// Server Error 5xx
-
-	InternalServerError           StatusCode = 500
-	NotImplemented                StatusCode = 501
-	BadGateway                    StatusCode = 502
-	ServiceUnavailable            StatusCode = 503
-	GatewayTimeout                StatusCode = 504
-	HTTPVersionNotSupported       StatusCode = 505
-	VariantAlsoNegotiates         StatusCode = 506
-	InsufficientStorage           StatusCode = 507
-	LoopDetected                  StatusCode = 508
-	NotExtended                   StatusCode = 510
-	NetworkAuthenticationRequired StatusCode = 511
-)
-
-// StatusCode Descriptions
-// --------------------------------------------------------------------
-
-const (
-
-	// Informational 1xx
-	ContinueDesc           Description = "Request received, processing continues"
-	SwitchingProtocolsDesc Description = "Server is switching protocols"
-	ProcessingDesc         Description = "Server is processing the request"
-
-	// Success 2xx
-
-	OKDesc                   Description = "Request succeeded and response contains requested data"
-	CreatedDesc              Description = "Resource created successfully and location provided"
-	AcceptedDesc             Description = "Request accepted for processing but processing not completed"
-	NonAuthoritativeInfoDesc Description = "Response contains non-authoritative information"
-	NoContentDesc            Description = "Request succeeded but no content returned"
-	ResetContentDesc         Description = "Request succeeded, client should reset document view"
-	PartialContentDesc       Description = "Partial content delivered as per range request"
-
-	// Redirection 3xx
-
-	MultipleChoicesDesc   Description = "Multiple options for resource available"
-	MovedPermanentlyDesc  Description = "Resource moved permanently to new location"
-	FoundDesc             Description = "Resource temporarily found at different location"
-	SeeOtherDesc          Description = "Client should get resource from different URI"
-	NotModifiedDesc       Description = "Resource not modified since last request"
-	UseProxyDesc          Description = "Requested resource must be accessed through proxy"
-	TemporaryRedirectDesc Description = "Resource temporarily moved to different location"
-	PermanentRedirectDesc Description = "Resource permanently moved to different location"
-
-	// Bad Request 4xx
-
-	BadRequestDesc                  Description = "Server cannot process request due to client error"
-	UnauthorizedDesc                Description = "Authentication required for resource access"
-	PaymentRequiredDesc             Description = "Payment required before processing request"
-	ForbiddenDesc                   Description = "Server refuses to fulfill request despite authentication"
-	NotFoundDesc                    Description = "Requested resource could not be found"
-	MethodNotAllowedDesc            Description = "Request method not supported for this resource"
-	NotAcceptableDesc               Description = "Resource cannot generate acceptable response"
-	ProxyAuthRequiredDesc           Description = "Authentication with proxy required"
-	RequestTimeoutDesc              Description = "Server timed out waiting for request"
-	ConflictDesc                    Description = "Request conflicts with current state of resource"
-	GoneDesc                        Description = "Resource permanently removed with no forwarding address"
-	LengthRequiredDesc              Description = "Content-Length header required for request"
-	PreconditionFailedDesc          Description = "Server precondition check failed"
-	PayloadTooLargeDesc             Description = "Request payload larger than server willing to process"
-	URITooLongDesc                  Description = "Request URI too long for server to process"
-	UnsupportedMediaTypeDesc        Description = "Media format not supported by server"
-	RangeNotSatisfiableDesc         Description = "Requested range cannot be satisfied"
-	ExpectationFailedDesc           Description = "Server cannot meet client expectation"
-	TeapotDesc                      Description = "I'm a teapot - RFC 2324 April Fools' joke"
-	UnprocessableEntityDesc         Description = "Request well-formed but semantically invalid"
-	TooEarlyDesc                    Description = "Server unwilling to risk processing due to replay attack"
-	UpgradeRequiredDesc             Description = "Client must switch to different protocol"
-	PreconditionRequiredDesc        Description = "Resource access requires conditional request"
-	TooManyRequestsDesc             Description = "Too many requests in given time period"
-	RequestHeaderFieldsTooLargeDesc Description = "Header fields too large for server to process"
-	UnavailableForLegalReasonsDesc  Description = "Resource access denied for legal reasons"
-
-	// Server Error 5xx
-
-	InternalServerErrorDesc           Description = "Server encountered unexpected condition"
-	NotImplementedDesc                Description = "Server does not support functionality required"
-	BadGatewayDesc                    Description = "Invalid response received from upstream server"
-	ServiceUnavailableDesc            Description = "Server temporarily unavailable"
-	GatewayTimeoutDesc                Description = "Upstream server failed to respond in time"
-	HTTPVersionNotSupportedDesc       Description = "HTTP version not supported by server"
-	VariantAlsoNegotiatesDesc         Description = "Server configuration error with transparent content negotiation"
-	InsufficientStorageDesc           Description = "Server unable to store resource to complete request"
-	LoopDetectedDesc                  Description = "Server detected infinite loop while processing request"
-	NotExtendedDesc                   Description = "Further extensions required to fulfill request"
-	NetworkAuthenticationRequiredDesc Description = "Client must authenticate to gain network access"
-)
-
-// StatusDescriptionMap maps status codes to their descriptions.
-//
-// Example:
-//
-//	desc := DescriptionMap[OK]
-//	fmt.Println(desc) // Output: "Request succeeded and response contains requested data"
-var StatusDescriptionMap = map[StatusCode]Description{
-	// 1xx Informational
-	Continue:           ContinueDesc,
-	SwitchingProtocols: SwitchingProtocolsDesc,
-	Processing:         ProcessingDesc,
-
-	// 2xx Success
-	OK:                   OKDesc,
-	Created:              CreatedDesc,
-	Accepted:             AcceptedDesc,
-	NonAuthoritativeInfo: NonAuthoritativeInfoDesc,
-	NoContent:            NoContentDesc,
-	ResetContent:         ResetContentDesc,
-	PartialContent:       PartialContentDesc,
-
-	// 3xx Redirection
-	MultipleChoices:   MultipleChoicesDesc,
-	MovedPermanently:  MovedPermanentlyDesc,
-	Found:             FoundDesc,
-	SeeOther:          SeeOtherDesc,
-	NotModified:       NotModifiedDesc,
-	UseProxy:          UseProxyDesc,
-	TemporaryRedirect: TemporaryRedirectDesc,
-	PermanentRedirect: PermanentRedirectDesc,
-
-	// 4xx Client Errors
-	BadRequest:                  BadRequestDesc,
-	Unauthorized:                UnauthorizedDesc,
-	PaymentRequired:             PaymentRequiredDesc,
-	Forbidden:                   ForbiddenDesc,
-	NotFound:                    NotFoundDesc,
-	MethodNotAllowed:            MethodNotAllowedDesc,
-	NotAcceptable:               NotAcceptableDesc,
-	ProxyAuthRequired:           ProxyAuthRequiredDesc,
-	RequestTimeout:              RequestTimeoutDesc,
-	Conflict:                    ConflictDesc,
-	Gone:                        GoneDesc,
-	LengthRequired:              LengthRequiredDesc,
-	PreconditionFailed:          PreconditionFailedDesc,
-	PayloadTooLarge:             PayloadTooLargeDesc,
-	URITooLong:                  URITooLongDesc,
-	UnsupportedMediaType:        UnsupportedMediaTypeDesc,
-	RangeNotSatisfiable:         RangeNotSatisfiableDesc,
-	ExpectationFailed:           ExpectationFailedDesc,
-	Teapot:                      TeapotDesc,
-	UnprocessableEntity:         UnprocessableEntityDesc,
-	TooEarly:                    TooEarlyDesc,
-	UpgradeRequired:             UpgradeRequiredDesc,
-	PreconditionRequired:        PreconditionRequiredDesc,
-	TooManyRequests:             TooManyRequestsDesc,
-	RequestHeaderFieldsTooLarge: RequestHeaderFieldsTooLargeDesc,
-	UnavailableForLegalReasons:  UnavailableForLegalReasonsDesc,
-
-	// 5xx Server Errors
-	InternalServerError:           InternalServerErrorDesc,
-	NotImplemented:                NotImplementedDesc,
-	BadGateway:                    BadGatewayDesc,
-	ServiceUnavailable:            ServiceUnavailableDesc,
-	GatewayTimeout:                GatewayTimeoutDesc,
-	HTTPVersionNotSupported:       HTTPVersionNotSupportedDesc,
-	VariantAlsoNegotiates:         VariantAlsoNegotiatesDesc,
-	InsufficientStorage:           InsufficientStorageDesc,
-	LoopDetected:                  LoopDetectedDesc,
-	NotExtended:                   NotExtendedDesc,
-	NetworkAuthenticationRequired: NetworkAuthenticationRequiredDesc,
-}
-
 // RegisterStatusCode registers a custom status code to the package's map of status codes.
 //
 // Note: Do not register built-in status codes (100-600).
-func RegisterStatusCode(code StatusCode, desc Description) {
+// Returns ErrSealed after Seal.
+func RegisterStatusCode(code StatusCode, desc Description) error {
 	mu.Lock()
+	if sealed.Load() {
+		mu.Unlock()
+		return sealedError()
+	}
+
 	// Skip Built In Codes
 	if code >= 100 && code <= 600 {
 		mu.Unlock()
-		return
+		return nil
 	}
 
+	old, existed := StatusDescriptionMap[code]
 	StatusDescriptionMap[code] = desc
+	notify := recordMutations(Mutation{Kind: MutationRegister, Code: code, Old: old, New: desc, Existed: existed})
 	mu.Unlock()
+
+	notify()
+	return nil
 }
 
 // DeleteStatusCode removes a custom status code from the package's map of status codes.
 // It takes a StatusCode as a parameter and deletes it from the map if it exists
 // and is not a built-in status code (100-600). The function is thread-safe and can
-// be called concurrently from multiple goroutines.
-func DeleteStatusCode(code StatusCode) {
+// be called concurrently from multiple goroutines. Returns ErrSealed after Seal.
+func DeleteStatusCode(code StatusCode) error {
 	mu.Lock()
+	if sealed.Load() {
+		mu.Unlock()
+		return sealedError()
+	}
+
 	// Skip Built In Codes
 	if code >= 100 && code <= 600 {
 		mu.Unlock()
-		return
+		return nil
 	}
 
-	if _, exists := StatusDescriptionMap[code]; !exists {
+	old, exists := StatusDescriptionMap[code]
+	if !exists {
 		mu.Unlock()
-		return
+		return nil
 	}
 	delete(StatusDescriptionMap, code)
+	notify := recordMutations(Mutation{Kind: MutationDelete, Code: code, Old: old, Existed: true})
 	mu.Unlock()
+
+	notify()
+	return nil
 }
 
 // Description String func
@@ -360,12 +162,27 @@ func ValidateStatusCode(code StatusCode) error {
 
 // GetStatusInfo returns a human-readable description of the status code.
 func GetStatusInfo(sc StatusCode) string {
+	defer rlock()()
 	if desc, exists := StatusDescriptionMap[sc]; exists {
 		return string(desc)
 	}
 	return "Unknown Status Code"
 }
 
+// GetStatusPhrase returns the standard reason phrase of the status code.
+func GetStatusPhrase(sc StatusCode) string {
+	defer rlock()()
+	return statusPhraseLocked(sc)
+}
+
+// statusPhraseLocked returns the phrase of GetStatusPhrase. mu must be held or the registry sealed.
+func statusPhraseLocked(sc StatusCode) string {
+	if phrase, exists := StatusPhraseMap[sc]; exists {
+		return phrase
+	}
+	return "Unknown Status Code"
+}
+
 // String returns a string representation of the status code.
 func (sc StatusCode) String() string {
 	return fmt.Sprintf("%d -> %s", int(sc), GetStatusInfo(sc))
@@ -373,7 +190,7 @@ func (sc StatusCode) String() string {
 
 // Print prints a string representation of the status code to the console.
 func (sc StatusCode) Print() {
-	fmt.Println(sc.String())
+	sc.Fprint(os.Stdout)
 }
 
 // CallMap returns a map of status codes to their descriptions.
@@ -381,112 +198,77 @@ func (sc StatusCode) CallMap() map[StatusCode]Description {
 	return StatusDescriptionMap
 }
 
-// Method Constants
+// Method Registration
 // --------------------------------------------------------------------
 
-// Method is a type for HTTP request methods.
-const (
-	GET     Method = "GET"
-	POST    Method = "POST"
-	PUT     Method = "PUT"
-	DELETE  Method = "DELETE"
-	PATCH   Method = "PATCH"
-	HEAD    Method = "HEAD"
-	OPTIONS Method = "OPTIONS"
-	CONNECT Method = "CONNECT"
-	TRACE   Method = "TRACE"
-)
-
-// Method Descriptions
-const (
-	GETDesc     Description = "Retrieve data from server"
-	POSTDesc    Description = "Send data to server for processing"
-	PUTDesc     Description = "Update data on server"
-	DELETEDesc  Description = "Delete data from server"
-	PATCHDesc   Description = "Partially update data on server"
-	HEADDesc    Description = "Retrieve metadata from server"
-	OPTIONSDesc Description = "Retrieve options from server"
-	CONNECTDesc Description = "Connect to server"
-	TRACEDesc   Description = "Trace route to server"
-)
-
-// MethodDescriptionMap maps HTTP methods to their descriptions.
-//
-// Map:
-//   - GET: "Retrieve data from server"
-//   - POST: "Send data to server for processing"
-//   - PUT: "Update data on server"
-//   - DELETE: "Delete data from server"
-//   - PATCH: "Partially update data on server"
-//   - HEAD: "Retrieve metadata from server"
-//   - OPTIONS: "Retrieve options from server"
-//   - CONNECT: "Connect to server"
-//   - TRACE: "Trace route to server"
-//
-// Example:
-//
-//	desc := MethodDescriptionMap[GET]
-//	fmt.Println(desc) // Output: "Retrieve data from server"
-var MethodDescriptionMap = map[Method]Description{
-	GET:     GETDesc,
-	POST:    POSTDesc,
-	PUT:     PUTDesc,
-	DELETE:  DELETEDesc,
-	PATCH:   PATCHDesc,
-	HEAD:    HEADDesc,
-	OPTIONS: OPTIONSDesc,
-	CONNECT: CONNECTDesc,
-	TRACE:   TRACEDesc,
-}
-
 // RegisterMethod adds a custom HTTP method to the package's map of methods.
 // It locks the map, checks if the method is empty or a standard method, and if not,
 // adds it to the MethodDescriptionMap with its description. The function ensures
-// thread safety using a mutex lock and unlock mechanism.
-func RegisterMethod(method Method, description Description) {
+// thread safety using a mutex lock and unlock mechanism. Returns ErrSealed after Seal.
+func RegisterMethod(method Method, description Description) error {
 	mu.Lock()
+	if sealed.Load() {
+		mu.Unlock()
+		return sealedError()
+	}
+
 	if method == "" {
 		mu.Unlock()
-		return
+		return nil
 	}
 
 	if method == GET || method == POST || method == PUT || method == DELETE || method == PATCH || method == HEAD || method == OPTIONS || method == CONNECT || method == TRACE {
 		mu.Unlock()
-		return
+		return nil
 	}
 
+	old, existed := MethodDescriptionMap[method]
 	MethodDescriptionMap[method] = description
+	notify := recordMutations(Mutation{Kind: MutationRegister, Method: method, Old: old, New: description, Existed: existed})
 	mu.Unlock()
+
+	notify()
+	return nil
 }
 
 // DeleteMethod removes a custom HTTP method from the package's map of methods.
 // It takes a Method as a parameter, locks the map, checks if the method is empty
 // or a standard method, and if not, deletes it from the MethodDescriptionMap.
 // The function is thread-safe and can be called concurrently from multiple
-// goroutines.
-func DeleteMethod(method Method) {
+// goroutines. Returns ErrSealed after Seal.
+func DeleteMethod(method Method) error {
 	mu.Lock()
+	if sealed.Load() {
+		mu.Unlock()
+		return sealedError()
+	}
 
 	if method == "" {
 		mu.Unlock()
-		return
+		return nil
 	}
 
 	if method == GET || method == POST || method == PUT || method == DELETE || method == PATCH || method == HEAD || method == OPTIONS || method == CONNECT || method == TRACE {
 		mu.Unlock()
-		return
+		return nil
 	}
 
-	if _, exists := MethodDescriptionMap[method]; !exists {
+	old, exists := MethodDescriptionMap[method]
+	if !exists {
 		mu.Unlock()
-		return
+		return nil
 	}
 	delete(MethodDescriptionMap, method)
+	notify := recordMutations(Mutation{Kind: MutationDelete, Method: method, Old: old, Existed: true})
 	mu.Unlock()
+
+	notify()
+	return nil
 }
 
 // GetMethodDescription returns a human-readable description of the HTTP method.
 func GetMethodDescription(method Method) string {
+	defer rlock()()
 	if desc, exists := MethodDescriptionMap[method]; exists {
 		return string(desc)
 	}
@@ -498,13 +280,31 @@ func GetMethodDescription(method Method) string {
 
 // ValidateMethod validates the method and returns an error if it's invalid.
 func ValidateMethod(method Method) error {
+	unlock := rlock()
 	_, ok := MethodDescriptionMap[method]
+	unlock()
+
 	if !ok {
 		return fmt.Errorf("invalid method: %s", method)
 	}
 	return nil
 }
 
+// IsSafeMethod checks if the method is safe, meaning it is essentially read-only,
+// according to the IANA HTTP Method Registry (GET, HEAD, OPTIONS, TRACE, ...).
+func IsSafeMethod(method Method) bool {
+	defer rlock()()
+	return safeMethods[method]
+}
+
+// IsIdempotentMethod checks if repeating the method has the same effect as
+// sending it once, according to the IANA HTTP Method Registry (safe methods,
+// PUT, DELETE, ...).
+func IsIdempotentMethod(method Method) bool {
+	defer rlock()()
+	return idempotentMethods[method]
+}
+
 // String returns a string representation of the method.
 func (m Method) String() string {
 	return fmt.Sprintf("%v -> %s", string(m), GetMethodDescription(m))
@@ -512,7 +312,7 @@ func (m Method) String() string {
 
 // Print prints a string representation of the method to the console.
 func (m Method) Print() {
-	fmt.Println(m.String())
+	m.Fprint(os.Stdout)
 }
 
 // CallMap returns a map of methods to their descriptions.
@@ -525,13 +325,13 @@ func (m Method) CallMap() map[Method]Description {
 
 // StringMap takes a map of StatusCode to Description and returns a string
 // representation of it, with each key-value pair separated by a line break.
-// Each key-value pair is formatted as "code -> description".
+// Each key-value pair is formatted as "code -> description", sorted by code.
 func StringStatusCodeMap(m map[StatusCode]Description) string {
 	var sb strings.Builder
 	sb.Grow(len(m) * 20)
 
-	for k, v := range m {
-		sb.WriteString(fmt.Sprintf("%d -> %s\n", k, v))
+	for _, k := range SortedStatusCodes(m, OrderByCode) {
+		sb.WriteString(fmt.Sprintf("%d -> %s\n", k, m[k]))
 	}
 	return sb.String()
 }
@@ -546,18 +346,18 @@ func StringStatusCodeMap(m map[StatusCode]Description) string {
 //	m := codes.StatusDescriptionMap
 //	codes.PrintMap(m) // Output: "100 -> Continue ..."
 func PrintStatusCodeMap(m map[StatusCode]Description) {
-	fmt.Println(StringStatusCodeMap(m))
+	FprintStatusCodeMap(os.Stdout, m)
 }
 
 // StringMethodMap takes a map of Method to Description and returns a string
 // representation of it, with each key-value pair separated by a line break.
-// Each key-value pair is formatted as "method -> description".
+// Each key-value pair is formatted as "method -> description", sorted by name.
 func StringMethodMap(m map[Method]Description) string {
 	var sb strings.Builder
 	sb.Grow(len(m) * 20)
 
-	for k, _ := range m {
-		sb.WriteString(fmt.Sprintf("%s\n", k.String()))
+	for _, k := range SortedMethods(m, OrderByCode) {
+		sb.WriteString(fmt.Sprintf("%s -> %s\n", string(k), m[k]))
 	}
 	return sb.String()
 }
@@ -572,5 +372,5 @@ func StringMethodMap(m map[Method]Description) string {
 //	m := codes.MethodDescriptionMap
 //	codes.PrintMethodMap(m) // Output: "GET -> Retrieve data from server ..."
 func PrintMethodMap(m map[Method]Description) {
-	fmt.Println(StringMethodMap(m))
+	FprintMethodMap(os.Stdout, m)
 }
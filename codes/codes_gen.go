@@ -0,0 +1,577 @@
+// Code generated by internal/gen/gencodes from the IANA registries; DO NOT EDIT.
+
+package codes
+
+// Status Codes Constants
+// --------------------------------------------------------------------
+
+// HTTP Status Codes
+const (
+
+	// Informational 1xx
+
+	Continue           StatusCode = 100
+	SwitchingProtocols StatusCode = 101
+	Processing         StatusCode = 102
+	EarlyHints         StatusCode = 103
+
+	// Success 2xx
+
+	OK                   StatusCode = 200
+	Created              StatusCode = 201
+	Accepted             StatusCode = 202
+	NonAuthoritativeInfo StatusCode = 203
+	NoContent            StatusCode = 204
+	ResetContent         StatusCode = 205
+	PartialContent       StatusCode = 206
+	MultiStatus          StatusCode = 207
+	AlreadyReported      StatusCode = 208
+	IMUsed               StatusCode = 226
+
+	// Redirection 3xx
+
+	MultipleChoices   StatusCode = 300
+	MovedPermanently  StatusCode = 301
+	Found             StatusCode = 302
+	SeeOther          StatusCode = 303
+	NotModified       StatusCode = 304
+	UseProxy          StatusCode = 305
+	TemporaryRedirect StatusCode = 307
+	PermanentRedirect StatusCode = 308
+
+	// Client Error 4xx
+
+	BadRequest                  StatusCode = 400
+	Unauthorized                StatusCode = 401
+	PaymentRequired             StatusCode = 402
+	Forbidden                   StatusCode = 403
+	NotFound                    StatusCode = 404
+	MethodNotAllowed            StatusCode = 405
+	NotAcceptable               StatusCode = 406
+	ProxyAuthRequired           StatusCode = 407
+	RequestTimeout              StatusCode = 408
+	Conflict                    StatusCode = 409
+	Gone                        StatusCode = 410
+	LengthRequired              StatusCode = 411
+	PreconditionFailed          StatusCode = 412
+	PayloadTooLarge             StatusCode = 413
+	URITooLong                  StatusCode = 414
+	UnsupportedMediaType        StatusCode = 415
+	RangeNotSatisfiable         StatusCode = 416
+	ExpectationFailed           StatusCode = 417
+	Teapot                      StatusCode = 418
+	MisdirectedRequest          StatusCode = 421
+	UnprocessableEntity         StatusCode = 422
+	Locked                      StatusCode = 423
+	FailedDependency            StatusCode = 424
+	TooEarly                    StatusCode = 425
+	UpgradeRequired             StatusCode = 426
+	PreconditionRequired        StatusCode = 428
+	TooManyRequests             StatusCode = 429
+	RequestHeaderFieldsTooLarge StatusCode = 431
+	UnavailableForLegalReasons  StatusCode = 451
+	ClientClosedRequest         StatusCode = 499
+
+	// Server Error 5xx
+
+	InternalServerError           StatusCode = 500
+	NotImplemented                StatusCode = 501
+	BadGateway                    StatusCode = 502
+	ServiceUnavailable            StatusCode = 503
+	GatewayTimeout                StatusCode = 504
+	HTTPVersionNotSupported       StatusCode = 505
+	VariantAlsoNegotiates         StatusCode = 506
+	InsufficientStorage           StatusCode = 507
+	LoopDetected                  StatusCode = 508
+	NotExtended                   StatusCode = 510
+	NetworkAuthenticationRequired StatusCode = 511
+)
+
+// StatusCode Descriptions
+// --------------------------------------------------------------------
+
+const (
+
+	// Informational 1xx
+
+	ContinueDesc           Description = "Request received, processing continues"
+	SwitchingProtocolsDesc Description = "Server is switching protocols"
+	ProcessingDesc         Description = "Server is processing the request"
+	EarlyHintsDesc         Description = "Preliminary headers sent before final response"
+
+	// Success 2xx
+
+	OKDesc                   Description = "Request succeeded and response contains requested data"
+	CreatedDesc              Description = "Resource created successfully and location provided"
+	AcceptedDesc             Description = "Request accepted for processing but processing not completed"
+	NonAuthoritativeInfoDesc Description = "Response contains non-authoritative information"
+	NoContentDesc            Description = "Request succeeded but no content returned"
+	ResetContentDesc         Description = "Request succeeded, client should reset document view"
+	PartialContentDesc       Description = "Partial content delivered as per range request"
+	MultiStatusDesc          Description = "Response contains status for multiple independent operations"
+	AlreadyReportedDesc      Description = "Members already enumerated in a previous part of the response"
+	IMUsedDesc               Description = "Response is the result of instance manipulations applied to the resource"
+
+	// Redirection 3xx
+
+	MultipleChoicesDesc   Description = "Multiple options for resource available"
+	MovedPermanentlyDesc  Description = "Resource moved permanently to new location"
+	FoundDesc             Description = "Resource temporarily found at different location"
+	SeeOtherDesc          Description = "Client should get resource from different URI"
+	NotModifiedDesc       Description = "Resource not modified since last request"
+	UseProxyDesc          Description = "Requested resource must be accessed through proxy"
+	TemporaryRedirectDesc Description = "Resource temporarily moved to different location"
+	PermanentRedirectDesc Description = "Resource permanently moved to different location"
+
+	// Client Error 4xx
+
+	BadRequestDesc                  Description = "Server cannot process request due to client error"
+	UnauthorizedDesc                Description = "Authentication required for resource access"
+	PaymentRequiredDesc             Description = "Payment required before processing request"
+	ForbiddenDesc                   Description = "Server refuses to fulfill request despite authentication"
+	NotFoundDesc                    Description = "Requested resource could not be found"
+	MethodNotAllowedDesc            Description = "Request method not supported for this resource"
+	NotAcceptableDesc               Description = "Resource cannot generate acceptable response"
+	ProxyAuthRequiredDesc           Description = "Authentication with proxy required"
+	RequestTimeoutDesc              Description = "Server timed out waiting for request"
+	ConflictDesc                    Description = "Request conflicts with current state of resource"
+	GoneDesc                        Description = "Resource permanently removed with no forwarding address"
+	LengthRequiredDesc              Description = "Content-Length header required for request"
+	PreconditionFailedDesc          Description = "Server precondition check failed"
+	PayloadTooLargeDesc             Description = "Request payload larger than server willing to process"
+	URITooLongDesc                  Description = "Request URI too long for server to process"
+	UnsupportedMediaTypeDesc        Description = "Media format not supported by server"
+	RangeNotSatisfiableDesc         Description = "Requested range cannot be satisfied"
+	ExpectationFailedDesc           Description = "Server cannot meet client expectation"
+	TeapotDesc                      Description = "I'm a teapot - RFC 2324 April Fools' joke"
+	MisdirectedRequestDesc          Description = "Request directed at server unable to produce a response"
+	UnprocessableEntityDesc         Description = "Request well-formed but semantically invalid"
+	LockedDesc                      Description = "Resource being accessed is locked"
+	FailedDependencyDesc            Description = "Request failed because a previous request failed"
+	TooEarlyDesc                    Description = "Server unwilling to risk processing due to replay attack"
+	UpgradeRequiredDesc             Description = "Client must switch to different protocol"
+	PreconditionRequiredDesc        Description = "Resource access requires conditional request"
+	TooManyRequestsDesc             Description = "Too many requests in given time period"
+	RequestHeaderFieldsTooLargeDesc Description = "Header fields too large for server to process"
+	UnavailableForLegalReasonsDesc  Description = "Resource access denied for legal reasons"
+	ClientClosedRequestDesc         Description = "Client closed the connection before the server responded"
+
+	// Server Error 5xx
+
+	InternalServerErrorDesc           Description = "Server encountered unexpected condition"
+	NotImplementedDesc                Description = "Server does not support functionality required"
+	BadGatewayDesc                    Description = "Invalid response received from upstream server"
+	ServiceUnavailableDesc            Description = "Server temporarily unavailable"
+	GatewayTimeoutDesc                Description = "Upstream server failed to respond in time"
+	HTTPVersionNotSupportedDesc       Description = "HTTP version not supported by server"
+	VariantAlsoNegotiatesDesc         Description = "Server configuration error with transparent content negotiation"
+	InsufficientStorageDesc           Description = "Server unable to store resource to complete request"
+	LoopDetectedDesc                  Description = "Server detected infinite loop while processing request"
+	NotExtendedDesc                   Description = "Further extensions required to fulfill request"
+	NetworkAuthenticationRequiredDesc Description = "Client must authenticate to gain network access"
+)
+
+// StatusDescriptionMap maps status codes to their descriptions.
+//
+// Example:
+//
+//	desc := StatusDescriptionMap[OK]
+//	fmt.Println(desc) // Output: "Request succeeded and response contains requested data"
+var StatusDescriptionMap = map[StatusCode]Description{
+	// 1xx Informational
+	Continue:           ContinueDesc,
+	SwitchingProtocols: SwitchingProtocolsDesc,
+	Processing:         ProcessingDesc,
+	EarlyHints:         EarlyHintsDesc,
+
+	// 2xx Success
+	OK:                   OKDesc,
+	Created:              CreatedDesc,
+	Accepted:             AcceptedDesc,
+	NonAuthoritativeInfo: NonAuthoritativeInfoDesc,
+	NoContent:            NoContentDesc,
+	ResetContent:         ResetContentDesc,
+	PartialContent:       PartialContentDesc,
+	MultiStatus:          MultiStatusDesc,
+	AlreadyReported:      AlreadyReportedDesc,
+	IMUsed:               IMUsedDesc,
+
+	// 3xx Redirection
+	MultipleChoices:   MultipleChoicesDesc,
+	MovedPermanently:  MovedPermanentlyDesc,
+	Found:             FoundDesc,
+	SeeOther:          SeeOtherDesc,
+	NotModified:       NotModifiedDesc,
+	UseProxy:          UseProxyDesc,
+	TemporaryRedirect: TemporaryRedirectDesc,
+	PermanentRedirect: PermanentRedirectDesc,
+
+	// 4xx Client Errors
+	BadRequest:                  BadRequestDesc,
+	Unauthorized:                UnauthorizedDesc,
+	PaymentRequired:             PaymentRequiredDesc,
+	Forbidden:                   ForbiddenDesc,
+	NotFound:                    NotFoundDesc,
+	MethodNotAllowed:            MethodNotAllowedDesc,
+	NotAcceptable:               NotAcceptableDesc,
+	ProxyAuthRequired:           ProxyAuthRequiredDesc,
+	RequestTimeout:              RequestTimeoutDesc,
+	Conflict:                    ConflictDesc,
+	Gone:                        GoneDesc,
+	LengthRequired:              LengthRequiredDesc,
+	PreconditionFailed:          PreconditionFailedDesc,
+	PayloadTooLarge:             PayloadTooLargeDesc,
+	URITooLong:                  URITooLongDesc,
+	UnsupportedMediaType:        UnsupportedMediaTypeDesc,
+	RangeNotSatisfiable:         RangeNotSatisfiableDesc,
+	ExpectationFailed:           ExpectationFailedDesc,
+	Teapot:                      TeapotDesc,
+	MisdirectedRequest:          MisdirectedRequestDesc,
+	UnprocessableEntity:         UnprocessableEntityDesc,
+	Locked:                      LockedDesc,
+	FailedDependency:            FailedDependencyDesc,
+	TooEarly:                    TooEarlyDesc,
+	UpgradeRequired:             UpgradeRequiredDesc,
+	PreconditionRequired:        PreconditionRequiredDesc,
+	TooManyRequests:             TooManyRequestsDesc,
+	RequestHeaderFieldsTooLarge: RequestHeaderFieldsTooLargeDesc,
+	UnavailableForLegalReasons:  UnavailableForLegalReasonsDesc,
+	ClientClosedRequest:         ClientClosedRequestDesc,
+
+	// 5xx Server Errors
+	InternalServerError:           InternalServerErrorDesc,
+	NotImplemented:                NotImplementedDesc,
+	BadGateway:                    BadGatewayDesc,
+	ServiceUnavailable:            ServiceUnavailableDesc,
+	GatewayTimeout:                GatewayTimeoutDesc,
+	HTTPVersionNotSupported:       HTTPVersionNotSupportedDesc,
+	VariantAlsoNegotiates:         VariantAlsoNegotiatesDesc,
+	InsufficientStorage:           InsufficientStorageDesc,
+	LoopDetected:                  LoopDetectedDesc,
+	NotExtended:                   NotExtendedDesc,
+	NetworkAuthenticationRequired: NetworkAuthenticationRequiredDesc,
+}
+
+// StatusPhraseMap maps status codes to their standard reason phrases,
+// as listed in the IANA HTTP Status Code Registry.
+//
+// Example:
+//
+//	phrase := StatusPhraseMap[NotFound]
+//	fmt.Println(phrase) // Output: "Not Found"
+var StatusPhraseMap = map[StatusCode]string{
+	// 1xx Informational
+	Continue:           "Continue",
+	SwitchingProtocols: "Switching Protocols",
+	Processing:         "Processing",
+	EarlyHints:         "Early Hints",
+
+	// 2xx Success
+	OK:                   "OK",
+	Created:              "Created",
+	Accepted:             "Accepted",
+	NonAuthoritativeInfo: "Non-Authoritative Information",
+	NoContent:            "No Content",
+	ResetContent:         "Reset Content",
+	PartialContent:       "Partial Content",
+	MultiStatus:          "Multi-Status",
+	AlreadyReported:      "Already Reported",
+	IMUsed:               "IM Used",
+
+	// 3xx Redirection
+	MultipleChoices:   "Multiple Choices",
+	MovedPermanently:  "Moved Permanently",
+	Found:             "Found",
+	SeeOther:          "See Other",
+	NotModified:       "Not Modified",
+	UseProxy:          "Use Proxy",
+	TemporaryRedirect: "Temporary Redirect",
+	PermanentRedirect: "Permanent Redirect",
+
+	// 4xx Client Errors
+	BadRequest:                  "Bad Request",
+	Unauthorized:                "Unauthorized",
+	PaymentRequired:             "Payment Required",
+	Forbidden:                   "Forbidden",
+	NotFound:                    "Not Found",
+	MethodNotAllowed:            "Method Not Allowed",
+	NotAcceptable:               "Not Acceptable",
+	ProxyAuthRequired:           "Proxy Authentication Required",
+	RequestTimeout:              "Request Timeout",
+	Conflict:                    "Conflict",
+	Gone:                        "Gone",
+	LengthRequired:              "Length Required",
+	PreconditionFailed:          "Precondition Failed",
+	PayloadTooLarge:             "Content Too Large",
+	URITooLong:                  "URI Too Long",
+	UnsupportedMediaType:        "Unsupported Media Type",
+	RangeNotSatisfiable:         "Range Not Satisfiable",
+	ExpectationFailed:           "Expectation Failed",
+	Teapot:                      "I'm a teapot",
+	MisdirectedRequest:          "Misdirected Request",
+	UnprocessableEntity:         "Unprocessable Content",
+	Locked:                      "Locked",
+	FailedDependency:            "Failed Dependency",
+	TooEarly:                    "Too Early",
+	UpgradeRequired:             "Upgrade Required",
+	PreconditionRequired:        "Precondition Required",
+	TooManyRequests:             "Too Many Requests",
+	RequestHeaderFieldsTooLarge: "Request Header Fields Too Large",
+	UnavailableForLegalReasons:  "Unavailable For Legal Reasons",
+	ClientClosedRequest:         "Client Closed Request",
+
+	// 5xx Server Errors
+	InternalServerError:           "Internal Server Error",
+	NotImplemented:                "Not Implemented",
+	BadGateway:                    "Bad Gateway",
+	ServiceUnavailable:            "Service Unavailable",
+	GatewayTimeout:                "Gateway Timeout",
+	HTTPVersionNotSupported:       "HTTP Version Not Supported",
+	VariantAlsoNegotiates:         "Variant Also Negotiates",
+	InsufficientStorage:           "Insufficient Storage",
+	LoopDetected:                  "Loop Detected",
+	NotExtended:                   "Not Extended",
+	NetworkAuthenticationRequired: "Network Authentication Required",
+}
+
+// StatusReferenceMap maps status codes to the specification defining them.
+var StatusReferenceMap = map[StatusCode]string{
+	// 1xx Informational
+	Continue:           "RFC 9110, Section 15.2.1",
+	SwitchingProtocols: "RFC 9110, Section 15.2.2",
+	Processing:         "RFC 2518",
+	EarlyHints:         "RFC 8297",
+
+	// 2xx Success
+	OK:                   "RFC 9110, Section 15.3.1",
+	Created:              "RFC 9110, Section 15.3.2",
+	Accepted:             "RFC 9110, Section 15.3.3",
+	NonAuthoritativeInfo: "RFC 9110, Section 15.3.4",
+	NoContent:            "RFC 9110, Section 15.3.5",
+	ResetContent:         "RFC 9110, Section 15.3.6",
+	PartialContent:       "RFC 9110, Section 15.3.7",
+	MultiStatus:          "RFC 4918",
+	AlreadyReported:      "RFC 5842",
+	IMUsed:               "RFC 3229",
+
+	// 3xx Redirection
+	MultipleChoices:   "RFC 9110, Section 15.4.1",
+	MovedPermanently:  "RFC 9110, Section 15.4.2",
+	Found:             "RFC 9110, Section 15.4.3",
+	SeeOther:          "RFC 9110, Section 15.4.4",
+	NotModified:       "RFC 9110, Section 15.4.5",
+	UseProxy:          "RFC 9110, Section 15.4.6",
+	TemporaryRedirect: "RFC 9110, Section 15.4.8",
+	PermanentRedirect: "RFC 9110, Section 15.4.9",
+
+	// 4xx Client Errors
+	BadRequest:                  "RFC 9110, Section 15.5.1",
+	Unauthorized:                "RFC 9110, Section 15.5.2",
+	PaymentRequired:             "RFC 9110, Section 15.5.3",
+	Forbidden:                   "RFC 9110, Section 15.5.4",
+	NotFound:                    "RFC 9110, Section 15.5.5",
+	MethodNotAllowed:            "RFC 9110, Section 15.5.6",
+	NotAcceptable:               "RFC 9110, Section 15.5.7",
+	ProxyAuthRequired:           "RFC 9110, Section 15.5.8",
+	RequestTimeout:              "RFC 9110, Section 15.5.9",
+	Conflict:                    "RFC 9110, Section 15.5.10",
+	Gone:                        "RFC 9110, Section 15.5.11",
+	LengthRequired:              "RFC 9110, Section 15.5.12",
+	PreconditionFailed:          "RFC 9110, Section 15.5.13",
+	PayloadTooLarge:             "RFC 9110, Section 15.5.14",
+	URITooLong:                  "RFC 9110, Section 15.5.15",
+	UnsupportedMediaType:        "RFC 9110, Section 15.5.16",
+	RangeNotSatisfiable:         "RFC 9110, Section 15.5.17",
+	ExpectationFailed:           "RFC 9110, Section 15.5.18",
+	Teapot:                      "RFC 9110, Section 15.5.19",
+	MisdirectedRequest:          "RFC 9110, Section 15.5.20",
+	UnprocessableEntity:         "RFC 9110, Section 15.5.21",
+	Locked:                      "RFC 4918",
+	FailedDependency:            "RFC 4918",
+	TooEarly:                    "RFC 8470",
+	UpgradeRequired:             "RFC 9110, Section 15.5.22",
+	PreconditionRequired:        "RFC 6585",
+	TooManyRequests:             "RFC 6585",
+	RequestHeaderFieldsTooLarge: "RFC 6585",
+	UnavailableForLegalReasons:  "RFC 7725",
+	ClientClosedRequest:         "nginx, ngx_http_request.h",
+
+	// 5xx Server Errors
+	InternalServerError:           "RFC 9110, Section 15.6.1",
+	NotImplemented:                "RFC 9110, Section 15.6.2",
+	BadGateway:                    "RFC 9110, Section 15.6.3",
+	ServiceUnavailable:            "RFC 9110, Section 15.6.4",
+	GatewayTimeout:                "RFC 9110, Section 15.6.5",
+	HTTPVersionNotSupported:       "RFC 9110, Section 15.6.6",
+	VariantAlsoNegotiates:         "RFC 2295",
+	InsufficientStorage:           "RFC 4918",
+	LoopDetected:                  "RFC 5842",
+	NotExtended:                   "RFC 2774; status-change-http-experiments-to-historic",
+	NetworkAuthenticationRequired: "RFC 6585",
+}
+
+// Method Constants
+// --------------------------------------------------------------------
+
+// Method is a type for HTTP request methods.
+const (
+	GET     Method = "GET"
+	POST    Method = "POST"
+	PUT     Method = "PUT"
+	DELETE  Method = "DELETE"
+	PATCH   Method = "PATCH"
+	HEAD    Method = "HEAD"
+	OPTIONS Method = "OPTIONS"
+	CONNECT Method = "CONNECT"
+	TRACE   Method = "TRACE"
+)
+
+// Method Descriptions
+const (
+	GETDesc     Description = "Retrieve data from server"
+	POSTDesc    Description = "Send data to server for processing"
+	PUTDesc     Description = "Update data on server"
+	DELETEDesc  Description = "Delete data from server"
+	PATCHDesc   Description = "Partially update data on server"
+	HEADDesc    Description = "Retrieve metadata from server"
+	OPTIONSDesc Description = "Retrieve options from server"
+	CONNECTDesc Description = "Connect to server"
+	TRACEDesc   Description = "Trace route to server"
+)
+
+// MethodDescriptionMap maps HTTP methods to their descriptions.
+//
+// Map:
+//   - GET: "Retrieve data from server"
+//   - POST: "Send data to server for processing"
+//   - PUT: "Update data on server"
+//   - DELETE: "Delete data from server"
+//   - PATCH: "Partially update data on server"
+//   - HEAD: "Retrieve metadata from server"
+//   - OPTIONS: "Retrieve options from server"
+//   - CONNECT: "Connect to server"
+//   - TRACE: "Trace route to server"
+//
+// Example:
+//
+//	desc := MethodDescriptionMap[GET]
+//	fmt.Println(desc) // Output: "Retrieve data from server"
+var MethodDescriptionMap = map[Method]Description{
+	GET:     GETDesc,
+	POST:    POSTDesc,
+	PUT:     PUTDesc,
+	DELETE:  DELETEDesc,
+	PATCH:   PATCHDesc,
+	HEAD:    HEADDesc,
+	OPTIONS: OPTIONSDesc,
+	CONNECT: CONNECTDesc,
+	TRACE:   TRACEDesc,
+}
+
+// MethodReferenceMap maps methods to the specification defining them.
+var MethodReferenceMap = map[Method]string{
+	GET:     "RFC 9110, Section 9.3.1",
+	POST:    "RFC 9110, Section 9.3.3",
+	PUT:     "RFC 9110, Section 9.3.4",
+	DELETE:  "RFC 9110, Section 9.3.5",
+	PATCH:   "RFC 5789, Section 2",
+	HEAD:    "RFC 9110, Section 9.3.2",
+	OPTIONS: "RFC 9110, Section 9.3.7",
+	CONNECT: "RFC 9110, Section 9.3.6",
+	TRACE:   "RFC 9110, Section 9.3.8",
+}
+
+// ianaMethods holds every method of the IANA registry.
+var ianaMethods = map[Method]bool{
+	"*":                 true,
+	"ACL":               true,
+	"BASELINE-CONTROL":  true,
+	"BIND":              true,
+	"CHECKIN":           true,
+	"CHECKOUT":          true,
+	CONNECT:             true,
+	"COPY":              true,
+	DELETE:              true,
+	GET:                 true,
+	HEAD:                true,
+	"LABEL":             true,
+	"LINK":              true,
+	"LOCK":              true,
+	"MERGE":             true,
+	"MKACTIVITY":        true,
+	"MKCALENDAR":        true,
+	"MKCOL":             true,
+	"MKREDIRECTREF":     true,
+	"MKWORKSPACE":       true,
+	"MOVE":              true,
+	OPTIONS:             true,
+	"ORDERPATCH":        true,
+	PATCH:               true,
+	POST:                true,
+	"PRI":               true,
+	"PROPFIND":          true,
+	"PROPPATCH":         true,
+	PUT:                 true,
+	"REBIND":            true,
+	"REPORT":            true,
+	"SEARCH":            true,
+	TRACE:               true,
+	"UNBIND":            true,
+	"UNCHECKOUT":        true,
+	"UNLINK":            true,
+	"UNLOCK":            true,
+	"UPDATE":            true,
+	"UPDATEREDIRECTREF": true,
+	"VERSION-CONTROL":   true,
+}
+
+// safeMethods holds every method of the IANA registry defined as safe.
+var safeMethods = map[Method]bool{
+	GET:        true,
+	HEAD:       true,
+	OPTIONS:    true,
+	"PRI":      true,
+	"PROPFIND": true,
+	"REPORT":   true,
+	"SEARCH":   true,
+	TRACE:      true,
+}
+
+// idempotentMethods holds every method of the IANA registry defined as idempotent.
+var idempotentMethods = map[Method]bool{
+	"ACL":               true,
+	"BASELINE-CONTROL":  true,
+	"BIND":              true,
+	"CHECKIN":           true,
+	"CHECKOUT":          true,
+	"COPY":              true,
+	DELETE:              true,
+	GET:                 true,
+	HEAD:                true,
+	"LABEL":             true,
+	"LINK":              true,
+	"MERGE":             true,
+	"MKACTIVITY":        true,
+	"MKCALENDAR":        true,
+	"MKCOL":             true,
+	"MKREDIRECTREF":     true,
+	"MKWORKSPACE":       true,
+	"MOVE":              true,
+	OPTIONS:             true,
+	"ORDERPATCH":        true,
+	"PRI":               true,
+	"PROPFIND":          true,
+	"PROPPATCH":         true,
+	PUT:                 true,
+	"REBIND":            true,
+	"REPORT":            true,
+	"SEARCH":            true,
+	TRACE:               true,
+	"UNBIND":            true,
+	"UNCHECKOUT":        true,
+	"UNLINK":            true,
+	"UNLOCK":            true,
+	"UPDATE":            true,
+	"UPDATEREDIRECTREF": true,
+	"VERSION-CONTROL":   true,
+}
@@ -0,0 +1,166 @@
+// Package codestest provides status code assertions for HTTP tests. Failure
+// messages show the phrase and description of both codes and an excerpt of
+// the body, instead of bare ints.
+//
+// Example:
+//
+//	func TestCreateOrder(t *testing.T) {
+//	    rec := httptest.NewRecorder()
+//	    handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/orders", body))
+//	    codestest.AssertStatus(t, rec, codes.Created)
+//	}
+//
+// Failure output:
+//
+//	unexpected status code
+//	    expected: 201 Created (Resource created successfully and location provided)
+//	    actual:   400 Bad Request (Server cannot process request due to client error)
+//	    body:     {"title":"Bad Request","detail":"name is required"}
+package codestest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+)
+
+// Assertion Types
+// --------------------------------------------------------------------
+
+// T is the part of testing.TB used by the assertions.
+type T interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Response is a response under test: a recorder or a client response.
+type Response interface {
+	*httptest.ResponseRecorder | *http.Response
+}
+
+// BodyExcerpt is the maximum number of body bytes shown in failure messages.
+var BodyExcerpt = 512
+
+// Assertion Funcs
+// --------------------------------------------------------------------
+
+// AssertStatus checks that the status code of r is want.
+//
+// Example:
+//
+//	codestest.AssertStatus(t, rec, codes.Created)
+func AssertStatus[R Response](t T, r R, want codes.StatusCode) bool {
+	t.Helper()
+	got, body := read(r)
+	if got == want {
+		return true
+	}
+	t.Errorf("unexpected status code\n    expected: %s\n    actual:   %s\n    body:     %s",
+		describe(want), describe(got), excerpt(body))
+	return false
+}
+
+// AssertClass checks that the status code of r is in class.
+//
+// Example:
+//
+//	codestest.AssertClass(t, resp, codes.ClientError)
+func AssertClass[R Response](t T, r R, class codes.Class) bool {
+	t.Helper()
+	got, body := read(r)
+	if class.Contains(got) {
+		return true
+	}
+	t.Errorf("unexpected status class\n    expected: %s %s\n    actual:   %s\n    body:     %s",
+		class, class.Name(), describe(got), excerpt(body))
+	return false
+}
+
+// AssertProblem checks that r has the status code want and a problem+json
+// body whose status, when set, is want. It returns the decoded problem
+// details, or nil when an assertion failed.
+//
+// Example:
+//
+//	if p := codestest.AssertProblem(t, rec, codes.NotFound); p != nil {
+//	    assert.Equal(t, "Order 42 does not exist", p.Detail)
+//	}
+func AssertProblem[R Response](t T, r R, want codes.StatusCode) *codes.Problem {
+	t.Helper()
+	if !AssertStatus(t, r, want) {
+		return nil
+	}
+
+	_, body := read(r)
+	contentType := header(r).Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != codes.ProblemContentType {
+		t.Errorf("unexpected content type\n    expected: %s\n    actual:   %s\n    body:     %s",
+			codes.ProblemContentType, contentType, excerpt(body))
+		return nil
+	}
+
+	var problem codes.Problem
+	if err := json.Unmarshal(body, &problem); err != nil {
+		t.Errorf("invalid problem details: %v\n    body:     %s", err, excerpt(body))
+		return nil
+	}
+	if problem.Status != 0 && problem.Status != want {
+		t.Errorf("unexpected problem status\n    expected: %s\n    actual:   %s\n    body:     %s",
+			describe(want), describe(problem.Status), excerpt(body))
+		return nil
+	}
+	return &problem
+}
+
+// read returns the status code and body of r. The body of a client
+// response is read and replaced, so it can still be read by the test.
+func read[R Response](r R) (codes.StatusCode, []byte) {
+	switch r := interface{}(r).(type) {
+	case *httptest.ResponseRecorder:
+		return codes.StatusCode(r.Code), r.Body.Bytes()
+	case *http.Response:
+		if r.Body == nil {
+			return codes.StatusCode(r.StatusCode), nil
+		}
+		body, _ := io.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		return codes.StatusCode(r.StatusCode), body
+	}
+	return 0, nil
+}
+
+// header returns the headers of r.
+func header[R Response](r R) http.Header {
+	switch r := interface{}(r).(type) {
+	case *httptest.ResponseRecorder:
+		return r.Header()
+	case *http.Response:
+		return r.Header
+	}
+	return nil
+}
+
+// describe returns "code phrase (description)".
+func describe(code codes.StatusCode) string {
+	return fmt.Sprintf("%d %s (%s)", int(code), codes.GetStatusPhrase(code), codes.GetStatusInfo(code))
+}
+
+// excerpt returns the start of body on one line, or "(empty)".
+func excerpt(body []byte) string {
+	s := strings.Join(strings.Fields(string(body)), " ")
+	if s == "" {
+		return "(empty)"
+	}
+	if len(s) > BodyExcerpt {
+		return strings.ToValidUTF8(s[:BodyExcerpt], "") + "..."
+	}
+	return s
+}
@@ -0,0 +1,243 @@
+package codestest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/internal/delay"
+)
+
+// Server Types
+// --------------------------------------------------------------------
+
+// Script is the scripted behaviour of a route.
+type Script struct {
+	// Codes are replied in order. The last code is then repeated, unless
+	// Loop is set or Classes is not empty.
+	Codes []codes.StatusCode
+	// Loop replays Codes from the start once they are exhausted.
+	Loop bool
+	// Classes are the weights of the classes drawn once Codes are exhausted.
+	// Classes reply with 200, 304, 400 or 503.
+	Classes map[codes.Class]float64
+	// Latency delays every response, until the request is canceled.
+	Latency time.Duration
+	// RetryAfter sets the Retry-After header of 429 and 503 responses.
+	RetryAfter time.Duration
+}
+
+// RecordedRequest is a request received by a ScriptedServer.
+type RecordedRequest struct {
+	Method codes.Method
+	Path   string
+	Code   codes.StatusCode
+	Time   time.Time
+}
+
+// ScriptedServer is an httptest.Server replying with scripted status codes
+// per route, for testing retries and circuit breakers deterministically.
+type ScriptedServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	scripts  map[string]Script
+	next     map[string]int
+	rand     *rand.Rand
+	requests []RecordedRequest
+}
+
+// classCodes are the status codes replied for drawn classes.
+var classCodes = map[codes.Class]codes.StatusCode{
+	codes.Success:     codes.OK,
+	codes.Redirection: codes.NotModified,
+	codes.ClientError: codes.BadRequest,
+	codes.ServerError: codes.ServiceUnavailable,
+}
+
+// ErrInvalidScript is returned for scripts that cannot be replied.
+var ErrInvalidScript = errors.New("invalid script")
+
+// Server Funcs
+// --------------------------------------------------------------------
+
+// NewScriptedServer starts a server replying with the scripts of its routes,
+// keyed by exact path. The script of "" applies to other paths, which
+// otherwise reply 404. Class draws use a fixed seed, see Seed. It panics
+// if a script is invalid, see Script.Validate.
+//
+// Example:
+//
+//	srv := codestest.NewScriptedServer(map[string]codestest.Script{
+//	    "/orders": {Codes: []codes.StatusCode{503, 503, 200}, RetryAfter: time.Second},
+//	    "/flaky":  {Classes: map[codes.Class]float64{codes.Success: 0.9, codes.ServerError: 0.1}},
+//	})
+//	defer srv.Close()
+func NewScriptedServer(scripts map[string]Script) *ScriptedServer {
+	s := &ScriptedServer{
+		scripts: map[string]Script{},
+		next:    map[string]int{},
+		rand:    rand.New(rand.NewSource(1)),
+	}
+	for route, script := range scripts {
+		mustValidate(route, script)
+		s.scripts[route] = script
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Script sets the script of a route and restarts its sequence. It panics if
+// the script is invalid, see Script.Validate.
+func (s *ScriptedServer) Script(route string, script Script) {
+	mustValidate(route, script)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[route] = script
+	delete(s.next, route)
+}
+
+// Seed sets the seed of the class draws.
+func (s *ScriptedServer) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rand = rand.New(rand.NewSource(seed))
+}
+
+// Requests returns the received requests, oldest first.
+func (s *ScriptedServer) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// Methods returns the methods of the requests received on path, oldest first.
+func (s *ScriptedServer) Methods(path string) []codes.Method {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var methods []codes.Method
+	for _, r := range s.requests {
+		if r.Path == path {
+			methods = append(methods, r.Method)
+		}
+	}
+	return methods
+}
+
+// Count returns the number of requests received on path.
+func (s *ScriptedServer) Count(path string) int {
+	return len(s.Methods(path))
+}
+
+// Reset clears the recorded requests and restarts every sequence.
+func (s *ScriptedServer) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+	s.next = map[string]int{}
+}
+
+// serveHTTP replies with the next status code of the route of r.
+func (s *ScriptedServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	route := r.URL.Path
+	script, exists := s.scripts[route]
+	if !exists {
+		route = ""
+		script, exists = s.scripts[route]
+	}
+	code := codes.NotFound
+	if exists {
+		code = s.nextCode(route, script)
+	}
+	s.requests = append(s.requests, RecordedRequest{Method: codes.Method(r.Method), Path: r.URL.Path, Code: code, Time: time.Now()})
+	s.mu.Unlock()
+
+	if script.Latency > 0 && !delay.Sleep(r.Context(), script.Latency) {
+		return
+	}
+
+	if script.RetryAfter > 0 && (code == codes.TooManyRequests || code == codes.ServiceUnavailable) {
+		seconds := (script.RetryAfter + time.Second - 1) / time.Second
+		w.Header().Set("Retry-After", strconv.Itoa(int(seconds)))
+	}
+
+	switch {
+	case code == codes.NoContent || code == codes.NotModified:
+		w.WriteHeader(int(code))
+	case code.Class() == codes.Success:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(int(code))
+		fmt.Fprintf(w, "%d %s\n", int(code), codes.GetStatusPhrase(code))
+	default:
+		codes.DefaultErrorPage.Render(w, r, code, nil)
+	}
+}
+
+// Validate checks the status codes of the script. They must be final status
+// codes, from 200 to 599, as a 1xx code does not end a response.
+func (s Script) Validate() error {
+	for _, code := range s.Codes {
+		if code < 200 || code > 599 {
+			return fmt.Errorf("%w: status code %d cannot be replied", ErrInvalidScript, int(code))
+		}
+	}
+	return nil
+}
+
+// mustValidate panics if the script of route is invalid.
+func mustValidate(route string, script Script) {
+	if err := script.Validate(); err != nil {
+		panic(fmt.Errorf("codestest: route %q: %w", route, err))
+	}
+}
+
+// nextCode returns the next status code of a script. s.mu must be held.
+func (s *ScriptedServer) nextCode(route string, script Script) codes.StatusCode {
+	i := s.next[route]
+	s.next[route] = i + 1
+
+	switch {
+	case i < len(script.Codes):
+		return script.Codes[i]
+	case len(script.Classes) > 0:
+		return s.drawClass(script.Classes)
+	case len(script.Codes) == 0:
+		return codes.OK
+	case script.Loop:
+		return script.Codes[i%len(script.Codes)]
+	}
+	return script.Codes[len(script.Codes)-1]
+}
+
+// drawClass returns the status code of a class drawn by weight. s.mu must be held.
+func (s *ScriptedServer) drawClass(weights map[codes.Class]float64) codes.StatusCode {
+	classes := make([]codes.Class, 0, len(weights))
+	total := 0.0
+	for class, weight := range weights {
+		if _, exists := classCodes[class]; exists && weight > 0 {
+			classes = append(classes, class)
+			total += weight
+		}
+	}
+	if total == 0 {
+		return codes.OK
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i] < classes[j] })
+
+	n := s.rand.Float64() * total
+	for _, class := range classes {
+		if n -= weights[class]; n < 0 {
+			return classCodes[class]
+		}
+	}
+	return classCodes[classes[len(classes)-1]]
+}
@@ -0,0 +1,157 @@
+package codes
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Early Hints Types
+// --------------------------------------------------------------------
+
+// Hint represents a single resource announced in a 103 Early Hints response.
+// It is rendered as a value of the Link header, for example:
+//
+//	</app.css>; rel=preload; as=style
+type Hint struct {
+	// URL of the resource to preload. Required.
+	URL string
+	// Rel is the link relation. Defaults to "preload" when empty.
+	Rel string
+	// As is the destination of the resource (style, script, font, image, ...).
+	As string
+	// Type is the optional MIME type of the resource.
+	Type string
+	// CrossOrigin is the optional CORS setting ("anonymous" or "use-credentials").
+	CrossOrigin string
+}
+
+// HintManifest maps request paths to the hints sent for them.
+//
+// A key ending in "*" matches every path with that prefix, any other key
+// matches the path exactly. Exact keys take precedence over prefix keys,
+// and longer prefixes take precedence over shorter ones.
+//
+// Example:
+//
+//	manifest := codes.HintManifest{
+//	    "/":        {{URL: "/app.css", As: "style"}},
+//	    "/docs/*":  {{URL: "/docs.js", As: "script"}},
+//	}
+type HintManifest map[string][]Hint
+
+// ErrEmptyHintURL is returned when a Hint has no URL.
+var ErrEmptyHintURL = errors.New("early hint has empty URL")
+
+// ErrNoHints is returned when no hints are given to WriteEarlyHints.
+var ErrNoHints = errors.New("no early hints to write")
+
+// Early Hints Funcs
+// --------------------------------------------------------------------
+
+// String returns the Link header value of the hint.
+func (h Hint) String() string {
+	rel := h.Rel
+	if rel == "" {
+		rel = "preload"
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(h.URL) + 32)
+
+	sb.WriteString("<")
+	sb.WriteString(h.URL)
+	sb.WriteString(">; rel=")
+	sb.WriteString(rel)
+	if h.As != "" {
+		sb.WriteString("; as=")
+		sb.WriteString(h.As)
+	}
+	if h.Type != "" {
+		sb.WriteString("; type=\"")
+		sb.WriteString(h.Type)
+		sb.WriteString("\"")
+	}
+	if h.CrossOrigin != "" {
+		sb.WriteString("; crossorigin=")
+		sb.WriteString(h.CrossOrigin)
+	}
+	return sb.String()
+}
+
+// WriteEarlyHints adds one Link header per hint to w and sends a single
+// 103 Early Hints informational response. Call it several times to send
+// several 103 responses before the final one.
+//
+// The Link headers stay in the header map, so they are repeated in the
+// final response, as recommended by RFC 8297.
+//
+// Note: It must be called before the final WriteHeader or Write.
+func WriteEarlyHints(w http.ResponseWriter, hints ...Hint) error {
+	if len(hints) == 0 {
+		return ErrNoHints
+	}
+
+	for _, h := range hints {
+		if h.URL == "" {
+			return ErrEmptyHintURL
+		}
+	}
+
+	header := w.Header()
+	for _, h := range hints {
+		header.Add("Link", h.String())
+	}
+
+	w.WriteHeader(int(EarlyHints))
+	return nil
+}
+
+// Lookup returns the hints registered for path, or nil if none match.
+func (hm HintManifest) Lookup(path string) []Hint {
+	if hints, exists := hm[path]; exists {
+		return hints
+	}
+
+	var (
+		best    []Hint
+		bestLen = -1
+	)
+	for key, hints := range hm {
+		if !strings.HasSuffix(key, "*") {
+			continue
+		}
+		prefix := strings.TrimSuffix(key, "*")
+		if strings.HasPrefix(path, prefix) && len(prefix) > bestLen {
+			best = hints
+			bestLen = len(prefix)
+		}
+	}
+	return best
+}
+
+// EarlyHintsMiddleware returns a middleware that sends a 103 Early Hints
+// response with the hints the manifest holds for the request path before
+// calling the next handler.
+//
+// Hints are only sent for GET requests over HTTP/1.1 or newer, since
+// HTTP/1.0 clients do not understand informational responses.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	handler := codes.EarlyHintsMiddleware(manifest)(mux)
+//	http.ListenAndServe(":8080", handler)
+func EarlyHintsMiddleware(manifest HintManifest) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if Method(r.Method) == GET && r.ProtoAtLeast(1, 1) {
+				if hints := manifest.Lookup(r.URL.Path); len(hints) > 0 {
+					// Invalid manifest entries must not break the request.
+					_ = WriteEarlyHints(w, hints...)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
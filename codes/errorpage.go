@@ -0,0 +1,365 @@
+package codes
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	htmltemplate "html/template"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+)
+
+// ErrorPage Types
+// --------------------------------------------------------------------
+
+//go:embed templates/*
+var defaultTemplates embed.FS
+
+// ErrorPageData is the data of the error page templates.
+type ErrorPageData struct {
+	MessageData
+	// Message is the end-user message, or the detail of the problem when set.
+	Message string
+	// Problem holds the problem details sent to JSON clients.
+	Problem *Problem
+}
+
+// ErrorPage renders error pages as HTML, JSON problem details or plain text,
+// following the Accept header of the request.
+//
+// HTML and text pages use templates named after the status code, then the
+// class, then "error": "404.html", "4xx.html", "error.html", and the same
+// with ".txt". Templates of the application override the embedded ones.
+type ErrorPage struct {
+	html map[string]*htmltemplate.Template
+	text map[string]*texttemplate.Template
+	// Values are passed to message and page templates as .Values.
+	Values map[string]string
+}
+
+// DefaultErrorPage renders error pages with the embedded templates.
+var DefaultErrorPage = mustErrorPage(nil)
+
+// Error page formats, in order of preference when the client accepts any.
+var errorPageOffers = []string{"text/html", ProblemContentType, "application/json", "text/plain"}
+
+// ErrorPage Funcs
+// --------------------------------------------------------------------
+
+// NewErrorPage returns an ErrorPage using the "*.html" and "*.txt" templates
+// at the root of fsys over the embedded ones. fsys may be nil.
+//
+// Example:
+//
+//	//go:embed errors
+//	var errorTemplates embed.FS
+//
+//	sub, _ := fs.Sub(errorTemplates, "errors")
+//	page, err := codes.NewErrorPage(sub)
+func NewErrorPage(fsys fs.FS) (*ErrorPage, error) {
+	p := &ErrorPage{
+		html: map[string]*htmltemplate.Template{},
+		text: map[string]*texttemplate.Template{},
+	}
+
+	defaults, err := fs.Sub(defaultTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	for _, fsys := range []fs.FS{defaults, fsys} {
+		if fsys == nil {
+			continue
+		}
+		if err := p.parse(fsys); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Render writes the error page of code, in the format accepted by the request.
+// err may be nil, a *Problem or an *HTTPError: its problem details, status
+// code and client message are then used. Internal causes are never shown.
+// Plain text is sent when the request refuses every format, and r may be nil.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    if err := process(r); err != nil {
+//	        codes.DefaultErrorPage.Render(w, r, codes.InternalServerError, err)
+//	        return
+//	    }
+//	}
+func (p *ErrorPage) Render(w http.ResponseWriter, r *http.Request, code StatusCode, err error) {
+	if r == nil {
+		r = &http.Request{Method: http.MethodGet, Header: http.Header{}, URL: &url.URL{}}
+	}
+	data := p.pageData(r, code, err)
+
+	format := negotiate(r.Header.Get("Accept"), errorPageOffers)
+	var body []byte
+	var contentType string
+	var renderErr error
+
+	switch format {
+	case ProblemContentType, "application/json":
+		contentType = format
+		body, renderErr = json.Marshal(data.Problem)
+		body = append(body, '\n')
+	case "text/plain", "":
+		contentType = "text/plain; charset=utf-8"
+		body, renderErr = p.executeText(data)
+	default:
+		contentType = "text/html; charset=utf-8"
+		body, renderErr = p.executeHTML(data)
+	}
+	if renderErr != nil {
+		contentType = "text/plain; charset=utf-8"
+		body = []byte(strconv.Itoa(int(data.Code)) + " " + data.Phrase + "\n")
+	}
+
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Language", data.Locale.String())
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Cache-Control", "no-store")
+	h.Add("Vary", "Accept")
+	h.Add("Vary", "Accept-Language")
+	w.WriteHeader(int(data.Code))
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+}
+
+// Handler returns a handler replying with the error page of code.
+//
+// Example:
+//
+//	mux.Handle("/maintenance", codes.DefaultErrorPage.Handler(codes.ServiceUnavailable))
+func (p *ErrorPage) Handler(code StatusCode) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.Render(w, r, code, nil)
+	})
+}
+
+// ServeHTTP serves the error page of the status code in the last path
+// segment, so "/errors/503" replies with a 503 page. Other paths, and codes
+// below 400, reply with a 404 page.
+//
+// Example:
+//
+//	mux.Handle("/errors/", codes.DefaultErrorPage)
+func (p *ErrorPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	code := NotFound
+	if n, err := strconv.Atoi(path.Base(r.URL.Path)); err == nil && n >= 400 && n <= 999 {
+		code = StatusCode(n)
+	}
+	p.Render(w, r, code, nil)
+}
+
+// parse adds the templates at the root of fsys, replacing those with the same name.
+func (p *ErrorPage) parse(fsys fs.FS) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		switch path.Ext(name) {
+		case ".html":
+			tmpl, err := htmltemplate.New(name).Parse(string(content))
+			if err != nil {
+				return err
+			}
+			p.html[strings.TrimSuffix(name, ".html")] = tmpl
+		case ".txt":
+			tmpl, err := texttemplate.New(name).Parse(string(content))
+			if err != nil {
+				return err
+			}
+			p.text[strings.TrimSuffix(name, ".txt")] = tmpl
+		}
+	}
+	return nil
+}
+
+// pageData builds the template data of a response to r. Status codes taken
+// from err outside 400-599, and codes WriteHeader rejects, become 500.
+func (p *ErrorPage) pageData(r *http.Request, code StatusCode, err error) ErrorPageData {
+	var problem *Problem
+	if pr, ok := clientProblem(err); ok {
+		problem = pr
+		if code == 0 {
+			code = pr.StatusCode()
+			if code < 400 {
+				code = InternalServerError
+			}
+		}
+	}
+	if code < 100 || code > 999 {
+		code = InternalServerError
+	}
+
+	msgData := NewMessageData(r, code)
+	msgData.Values = p.Values
+	msg, tag := safeMessage(msgData)
+
+	if problem == nil {
+		problem = NewProblem(code, msg)
+		problem.Title = msgData.Phrase
+	} else {
+		cp := *problem
+		problem = &cp
+		if problem.Detail != "" {
+			msg = problem.Detail
+		}
+	}
+	problem.Status = code
+	if problem.Instance == "" && r.URL != nil {
+		problem.Instance = r.URL.Path
+	}
+
+	msgData.Locale = tag
+	return ErrorPageData{MessageData: msgData, Message: msg, Problem: problem}
+}
+
+// clientProblem returns the problem of an *HTTPError or a *Problem in the
+// chain of err. Unlike ProblemOf, it never looks inside a *ResponseError, so
+// the details of an upstream service are not shown to clients.
+func clientProblem(err error) (*Problem, bool) {
+	switch e := err.(type) {
+	case nil, *ResponseError:
+		return nil, false
+	case *HTTPError:
+		return e.ToProblem(), true
+	case *Problem:
+		return e, true
+	case interface{ Unwrap() error }:
+		return clientProblem(e.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if p, ok := clientProblem(err); ok {
+				return p, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// templateNames returns the template names tried for code, most specific first.
+func templateNames(code StatusCode) []string {
+	return []string{strconv.Itoa(int(code)), code.Class().String(), "error"}
+}
+
+// executeHTML renders the HTML template of data.
+func (p *ErrorPage) executeHTML(data ErrorPageData) ([]byte, error) {
+	for _, name := range templateNames(data.Code) {
+		if tmpl, exists := p.html[name]; exists {
+			var b bytes.Buffer
+			err := tmpl.Execute(&b, data)
+			return b.Bytes(), err
+		}
+	}
+	return nil, fs.ErrNotExist
+}
+
+// executeText renders the text template of data.
+func (p *ErrorPage) executeText(data ErrorPageData) ([]byte, error) {
+	for _, name := range templateNames(data.Code) {
+		if tmpl, exists := p.text[name]; exists {
+			var b bytes.Buffer
+			err := tmpl.Execute(&b, data)
+			return b.Bytes(), err
+		}
+	}
+	return nil, fs.ErrNotExist
+}
+
+// mustErrorPage returns NewErrorPage(fsys) or panics.
+func mustErrorPage(fsys fs.FS) *ErrorPage {
+	p, err := NewErrorPage(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Negotiation
+// --------------------------------------------------------------------
+
+// negotiate returns the offer with the highest quality in an Accept header.
+// The most specific media range matching an offer sets its quality, ties keep
+// the order of offers and an empty header accepts the first offer. A header
+// matching no offer also gets the first one, but when it refuses every offer
+// it matches with q=0, negotiate returns "".
+func negotiate(accept string, offers []string) string {
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	best, bestQ, matched := "", 0.0, false
+	for _, offer := range offers {
+		q, specificity := 0.0, -1
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, partQ := parseAcceptPart(part)
+			if s := matchMediaType(mediaType, offer); s > specificity {
+				q, specificity = partQ, s
+			}
+		}
+		if specificity >= 0 {
+			matched = true
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	if best == "" && !matched {
+		return offers[0]
+	}
+	return best
+}
+
+// parseAcceptPart returns the media type and quality of an Accept header element.
+func parseAcceptPart(part string) (string, float64) {
+	fields := strings.Split(part, ";")
+	mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+	q := 1.0
+	for _, param := range fields[1:] {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if found && strings.EqualFold(key, "q") {
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				q = v
+			}
+		}
+	}
+	return mediaType, q
+}
+
+// matchMediaType returns how specifically mediaType matches offer: 2 for an
+// exact match, 1 for "type/*", 0 for "*/*" and -1 for no match.
+func matchMediaType(mediaType, offer string) int {
+	switch {
+	case mediaType == offer:
+		return 2
+	case mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(mediaType, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(mediaType, "*")):
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,170 @@
+package codes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// Error Mapping Types
+// --------------------------------------------------------------------
+
+// StatusCoder is implemented by errors carrying their status code, such as
+// HTTPError and Problem.
+type StatusCoder interface {
+	StatusCode() StatusCode
+}
+
+// errorMapping matches an error to a status code.
+type errorMapping struct {
+	id    int
+	match func(error) (StatusCode, bool)
+}
+
+// errorMappings holds the registered mappings, most recent last.
+var errorMappings struct {
+	sync.RWMutex
+	nextID int
+	list   []errorMapping
+}
+
+// Error Mapping Funcs
+// --------------------------------------------------------------------
+
+// FromError returns the status code to reply with for err. It tries in order:
+//
+//  1. A StatusCoder in the chain of err, such as an HTTPError
+//  2. The registered mappings, most recent first
+//  3. The standard library errors below
+//
+// Standard library errors:
+//
+//   - context.DeadlineExceeded and net.Error timeouts: 504 Gateway Timeout
+//   - context.Canceled: 499 Client Closed Request
+//   - fs.ErrNotExist: 404 Not Found
+//   - fs.ErrPermission: 403 Forbidden
+//   - *http.MaxBytesError: 413 Payload Too Large
+//   - *json.SyntaxError and *json.UnmarshalTypeError: 400 Bad Request
+//
+// A nil error returns 200 OK, other errors 500 Internal Server Error. Codes
+// outside 100-599 also return 500, so the result is always a valid response status.
+//
+// Example:
+//
+//	if err := process(r); err != nil {
+//	    code := codes.FromError(err)
+//	    http.Error(w, codes.GetStatusPhrase(code), int(code))
+//	    return
+//	}
+func FromError(err error) StatusCode {
+	if err == nil {
+		return OK
+	}
+
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		return statusOrInternal(coder.StatusCode())
+	}
+
+	errorMappings.RLock()
+	for i := len(errorMappings.list) - 1; i >= 0; i-- {
+		if code, ok := errorMappings.list[i].match(err); ok {
+			errorMappings.RUnlock()
+			return statusOrInternal(code)
+		}
+	}
+	errorMappings.RUnlock()
+
+	return stdErrorStatus(err)
+}
+
+// RegisterError maps errors matching target with errors.Is to code.
+// It returns a function removing the mapping.
+//
+// Example:
+//
+//	var ErrOrderPaid = errors.New("order already paid")
+//
+//	codes.RegisterError(ErrOrderPaid, codes.Conflict)
+//	codes.FromError(fmt.Errorf("paying: %w", ErrOrderPaid)) // 409
+func RegisterError(target error, code StatusCode) (remove func()) {
+	return RegisterErrorFunc(func(err error) (StatusCode, bool) {
+		return code, errors.Is(err, target)
+	})
+}
+
+// RegisterErrorType maps errors of type E, found with errors.As, to code.
+// It returns a function removing the mapping.
+//
+// Example:
+//
+//	codes.RegisterErrorType[*ValidationError](codes.UnprocessableEntity)
+func RegisterErrorType[E error](code StatusCode) (remove func()) {
+	return RegisterErrorFunc(func(err error) (StatusCode, bool) {
+		var target E
+		return code, errors.As(err, &target)
+	})
+}
+
+// RegisterErrorFunc adds a mapping returning the status code of the errors
+// it matches. It returns a function removing the mapping.
+//
+// Example:
+//
+//	codes.RegisterErrorFunc(func(err error) (codes.StatusCode, bool) {
+//	    var pgErr *pgconn.PgError
+//	    if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+//	        return codes.Conflict, true
+//	    }
+//	    return 0, false
+//	})
+func RegisterErrorFunc(fn func(err error) (StatusCode, bool)) (remove func()) {
+	errorMappings.Lock()
+	defer errorMappings.Unlock()
+	errorMappings.nextID++
+	id := errorMappings.nextID
+	errorMappings.list = append(errorMappings.list, errorMapping{id: id, match: fn})
+
+	return func() {
+		errorMappings.Lock()
+		defer errorMappings.Unlock()
+		for i, m := range errorMappings.list {
+			if m.id == id {
+				errorMappings.list = append(errorMappings.list[:i:i], errorMappings.list[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// stdErrorStatus returns the status code of standard library errors.
+func stdErrorStatus(err error) StatusCode {
+	var (
+		maxBytesErr *http.MaxBytesError
+		syntaxErr   *json.SyntaxError
+		typeErr     *json.UnmarshalTypeError
+		netErr      net.Error
+	)
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return GatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return ClientClosedRequest
+	case errors.Is(err, fs.ErrNotExist):
+		return NotFound
+	case errors.Is(err, fs.ErrPermission):
+		return Forbidden
+	case errors.As(err, &maxBytesErr):
+		return PayloadTooLarge
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return BadRequest
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return GatewayTimeout
+	}
+	return InternalServerError
+}
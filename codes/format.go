@@ -0,0 +1,92 @@
+package codes
+
+import (
+	"fmt"
+	"io"
+)
+
+// StatusCode Formatting
+// --------------------------------------------------------------------
+
+// Format implements fmt.Formatter. Width, precision and flags are honoured.
+//
+// Verbs:
+//   - %d: the number, "404"
+//   - %s: the reason phrase, "Not Found"
+//   - %q: the quoted reason phrase, "\"Not Found\""
+//   - %v: the String form, "404 -> Requested resource could not be found"
+//   - %+v: the full metadata, "404 Not Found [4xx Client Error] Requested resource could not be found"
+func (sc StatusCode) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd', 'x', 'X', 'o', 'b':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), int(sc))
+	case 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), GetStatusPhrase(sc))
+	case 'v':
+		if f.Flag('+') {
+			class := sc.Class()
+			fmt.Fprintf(f, "%d %s [%s %s] %s", int(sc), GetStatusPhrase(sc), class, class.Name(), GetStatusInfo(sc))
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), sc.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(codes.StatusCode=%d)", verb, int(sc))
+	}
+}
+
+// Fprint writes the String form of the status code and a newline to w.
+func (sc StatusCode) Fprint(w io.Writer) (int, error) {
+	return fmt.Fprintln(w, sc.String())
+}
+
+// Method Formatting
+// --------------------------------------------------------------------
+
+// Format implements fmt.Formatter. Width, precision and flags are honoured.
+//
+// Verbs:
+//   - %s: the method name, "PATCH"
+//   - %q: the quoted method name, "\"PATCH\""
+//   - %v: the String form, "PATCH -> Partially update data on server"
+//   - %+v: the full metadata, "PATCH [unsafe, non-idempotent] Partially update data on server"
+func (m Method) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), string(m))
+	case 'v':
+		if f.Flag('+') {
+			safe, idempotent := "unsafe", "non-idempotent"
+			if IsSafeMethod(m) {
+				safe = "safe"
+			}
+			if IsIdempotentMethod(m) {
+				idempotent = "idempotent"
+			}
+			fmt.Fprintf(f, "%s [%s, %s] %s", string(m), safe, idempotent, GetMethodDescription(m))
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), m.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(codes.Method=%s)", verb, string(m))
+	}
+}
+
+// Fprint writes the String form of the method and a newline to w.
+func (m Method) Fprint(w io.Writer) (int, error) {
+	return fmt.Fprintln(w, m.String())
+}
+
+// Map Formatting
+// --------------------------------------------------------------------
+
+// FprintStatusCodeMap writes the map of status codes to their descriptions to w.
+// The output matches PrintStatusCodeMap.
+func FprintStatusCodeMap(w io.Writer, m map[StatusCode]Description) (int, error) {
+	return fmt.Fprintln(w, StringStatusCodeMap(m))
+}
+
+// FprintMethodMap writes the map of methods to their descriptions to w.
+// The output matches PrintMethodMap.
+func FprintMethodMap(w io.Writer, m map[Method]Description) (int, error) {
+	return fmt.Fprintln(w, StringMethodMap(m))
+}
@@ -0,0 +1,314 @@
+// Package grpcmap maps HTTP status codes to gRPC status codes and back,
+// following the mapping documented by google.rpc.Code.
+//
+// The gRPC codes are defined by the package itself, with the same values as
+// google.golang.org/grpc/codes, so no gRPC dependency is needed:
+//
+//	st := status.New(grpccodes.Code(grpcmap.ToGRPC(codes.NotFound)), "not found")
+//
+// Example:
+//
+//	fmt.Println(grpcmap.ToGRPC(codes.TooManyRequests))      // Output: ResourceExhausted
+//	fmt.Println(int(grpcmap.FromGRPC(grpcmap.Unavailable))) // Output: 503
+//
+// Services whose conventions differ from google.rpc.Code, such as reporting
+// conflicts as AlreadyExists, override single codes with SetToGRPC and
+// SetFromGRPC.
+package grpcmap
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/internal/bimap"
+)
+
+// Code Types
+// --------------------------------------------------------------------
+
+// Code is a gRPC status code.
+type Code uint32
+
+// gRPC Codes
+const (
+	OK                 Code = 0
+	Canceled           Code = 1
+	Unknown            Code = 2
+	InvalidArgument    Code = 3
+	DeadlineExceeded   Code = 4
+	NotFound           Code = 5
+	AlreadyExists      Code = 6
+	PermissionDenied   Code = 7
+	ResourceExhausted  Code = 8
+	FailedPrecondition Code = 9
+	Aborted            Code = 10
+	OutOfRange         Code = 11
+	Unimplemented      Code = 12
+	Internal           Code = 13
+	Unavailable        Code = 14
+	DataLoss           Code = 15
+	Unauthenticated    Code = 16
+)
+
+// codeNames maps gRPC codes to their names.
+var codeNames = [...]string{
+	OK:                 "OK",
+	Canceled:           "Canceled",
+	Unknown:            "Unknown",
+	InvalidArgument:    "InvalidArgument",
+	DeadlineExceeded:   "DeadlineExceeded",
+	NotFound:           "NotFound",
+	AlreadyExists:      "AlreadyExists",
+	PermissionDenied:   "PermissionDenied",
+	ResourceExhausted:  "ResourceExhausted",
+	FailedPrecondition: "FailedPrecondition",
+	Aborted:            "Aborted",
+	OutOfRange:         "OutOfRange",
+	Unimplemented:      "Unimplemented",
+	Internal:           "Internal",
+	Unavailable:        "Unavailable",
+	DataLoss:           "DataLoss",
+	Unauthenticated:    "Unauthenticated",
+}
+
+// ErrInvalidCode is returned for gRPC codes outside 0-16.
+var ErrInvalidCode = errors.New("invalid gRPC code")
+
+// ErrInvalidStatus is returned for HTTP status codes outside 100-599.
+var ErrInvalidStatus = errors.New("invalid HTTP status code")
+
+// Mapping Types
+// --------------------------------------------------------------------
+
+// Mapper maps HTTP status codes and gRPC codes. A gRPC server and the HTTP
+// gateway in front of it can each use their own Mapper.
+type Mapper struct {
+	mapping *bimap.Map[Code]
+}
+
+// toGRPCMap is the default HTTP to gRPC mapping. Other codes map by class.
+var toGRPCMap = map[codes.StatusCode]Code{
+	codes.OK:                  OK,
+	codes.BadRequest:          InvalidArgument,
+	codes.Unauthorized:        Unauthenticated,
+	codes.Forbidden:           PermissionDenied,
+	codes.NotFound:            NotFound,
+	codes.RequestTimeout:      DeadlineExceeded,
+	codes.Conflict:            Aborted,
+	codes.PreconditionFailed:  FailedPrecondition,
+	codes.RangeNotSatisfiable: OutOfRange,
+	codes.TooManyRequests:     ResourceExhausted,
+	codes.ClientClosedRequest: Canceled,
+	codes.InternalServerError: Internal,
+	codes.NotImplemented:      Unimplemented,
+	codes.BadGateway:          Unavailable,
+	codes.ServiceUnavailable:  Unavailable,
+	codes.GatewayTimeout:      DeadlineExceeded,
+}
+
+// fromGRPCMap is the default gRPC to HTTP mapping.
+var fromGRPCMap = map[Code]codes.StatusCode{
+	OK:                 codes.OK,
+	Canceled:           codes.ClientClosedRequest,
+	Unknown:            codes.InternalServerError,
+	InvalidArgument:    codes.BadRequest,
+	DeadlineExceeded:   codes.GatewayTimeout,
+	NotFound:           codes.NotFound,
+	AlreadyExists:      codes.Conflict,
+	PermissionDenied:   codes.Forbidden,
+	ResourceExhausted:  codes.TooManyRequests,
+	FailedPrecondition: codes.BadRequest,
+	Aborted:            codes.Conflict,
+	OutOfRange:         codes.BadRequest,
+	Unimplemented:      codes.NotImplemented,
+	Internal:           codes.InternalServerError,
+	Unavailable:        codes.ServiceUnavailable,
+	DataLoss:           codes.InternalServerError,
+	Unauthenticated:    codes.Unauthorized,
+}
+
+// Default is the Mapper used by the package level functions.
+var Default = NewMapper()
+
+// Code Funcs
+// --------------------------------------------------------------------
+
+// String returns the name of the code, for example "NotFound".
+func (c Code) String() string {
+	if c.IsValid() {
+		return codeNames[c]
+	}
+	return "Code(" + strconv.FormatUint(uint64(c), 10) + ")"
+}
+
+// IsValid checks if the code is one of the 17 gRPC codes.
+func (c Code) IsValid() bool {
+	return c <= Unauthenticated
+}
+
+// ParseCode parses a gRPC code from its name ("NotFound", "NOT_FOUND",
+// case-insensitive) or its number ("5").
+//
+// Example:
+//
+//	code, err := grpcmap.ParseCode("RESOURCE_EXHAUSTED")
+//	fmt.Println(code) // Output: ResourceExhausted
+func ParseCode(s string) (Code, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseUint(s, 10, 32); err == nil {
+		if c := Code(n); c.IsValid() {
+			return c, nil
+		}
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCode, s)
+	}
+
+	name := strings.ReplaceAll(s, "_", "")
+	for c, n := range codeNames {
+		if strings.EqualFold(name, n) {
+			return Code(c), nil
+		}
+	}
+	if strings.EqualFold(name, "Cancelled") {
+		return Canceled, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidCode, s)
+}
+
+// MarshalText encodes the code as its name.
+func (c Code) MarshalText() ([]byte, error) {
+	if !c.IsValid() {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidCode, uint32(c))
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a code, see ParseCode.
+func (c *Code) UnmarshalText(text []byte) error {
+	code, err := ParseCode(string(text))
+	if err != nil {
+		return err
+	}
+	*c = code
+	return nil
+}
+
+// Mapper Funcs
+// --------------------------------------------------------------------
+
+// NewMapper returns a Mapper with the default mapping.
+func NewMapper() *Mapper {
+	return &Mapper{mapping: bimap.New(toGRPCMap, fromGRPCMap)}
+}
+
+// ToGRPC returns the gRPC code of an HTTP status code. Codes without a
+// mapping map by class: 2xx to OK, 4xx to FailedPrecondition, others to Unknown.
+func (m *Mapper) ToGRPC(status codes.StatusCode) Code {
+	if code, exists := m.mapping.To(status); exists {
+		return code
+	}
+
+	switch status.Class() {
+	case codes.Success:
+		return OK
+	case codes.ClientError:
+		return FailedPrecondition
+	}
+	return Unknown
+}
+
+// FromGRPC returns the HTTP status code of a gRPC code. Invalid codes
+// map to 500 Internal Server Error.
+func (m *Mapper) FromGRPC(code Code) codes.StatusCode {
+	if status, exists := m.mapping.From(code); exists {
+		return status
+	}
+	return codes.InternalServerError
+}
+
+// SetToGRPC overrides the gRPC code an HTTP status code maps to.
+//
+// Example:
+//
+//	// Conflicts are duplicate resources in our API
+//	m.SetToGRPC(codes.Conflict, grpcmap.AlreadyExists)
+func (m *Mapper) SetToGRPC(status codes.StatusCode, code Code) error {
+	if err := validate(status, code); err != nil {
+		return err
+	}
+	m.mapping.SetTo(status, code)
+	return nil
+}
+
+// SetFromGRPC overrides the HTTP status code a gRPC code maps to.
+//
+// Example:
+//
+//	m.SetFromGRPC(grpcmap.FailedPrecondition, codes.PreconditionFailed)
+func (m *Mapper) SetFromGRPC(code Code, status codes.StatusCode) error {
+	if err := validate(status, code); err != nil {
+		return err
+	}
+	m.mapping.SetFrom(code, status)
+	return nil
+}
+
+// Override maps an HTTP status code and a gRPC code to each other.
+func (m *Mapper) Override(status codes.StatusCode, code Code) error {
+	if err := validate(status, code); err != nil {
+		return err
+	}
+	m.mapping.Override(status, code)
+	return nil
+}
+
+// Reset restores the default mapping, removing all overrides.
+func (m *Mapper) Reset() {
+	m.mapping.Reset()
+}
+
+// Package Funcs
+// --------------------------------------------------------------------
+
+// ToGRPC returns the gRPC code of an HTTP status code, using Default.
+func ToGRPC(status codes.StatusCode) Code {
+	return Default.ToGRPC(status)
+}
+
+// FromGRPC returns the HTTP status code of a gRPC code, using Default.
+func FromGRPC(code Code) codes.StatusCode {
+	return Default.FromGRPC(code)
+}
+
+// SetToGRPC overrides the mapping of an HTTP status code in Default.
+func SetToGRPC(status codes.StatusCode, code Code) error {
+	return Default.SetToGRPC(status, code)
+}
+
+// SetFromGRPC overrides the mapping of a gRPC code in Default.
+func SetFromGRPC(code Code, status codes.StatusCode) error {
+	return Default.SetFromGRPC(code, status)
+}
+
+// Override maps an HTTP status code and a gRPC code to each other in Default.
+func Override(status codes.StatusCode, code Code) error {
+	return Default.Override(status, code)
+}
+
+// Reset restores the default mapping of Default.
+func Reset() {
+	Default.Reset()
+}
+
+// validate checks the codes of an override.
+func validate(status codes.StatusCode, code Code) error {
+	if !code.IsValid() {
+		return fmt.Errorf("%w: %d", ErrInvalidCode, uint32(code))
+	}
+	if !codes.IsValidStatusCode(status) {
+		return fmt.Errorf("%w: %d", ErrInvalidStatus, int(status))
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package codes
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Hook Types
+// --------------------------------------------------------------------
+
+// MutationKind tells whether a Mutation registered or deleted an entry.
+type MutationKind int
+
+// Mutation Kinds
+const (
+	MutationRegister MutationKind = iota + 1
+	MutationDelete
+)
+
+// String returns "register" or "delete".
+func (k MutationKind) String() string {
+	switch k {
+	case MutationRegister:
+		return "register"
+	case MutationDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// Caller is the code location that mutated the registry.
+type Caller struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// String returns the location as "file:line".
+func (c Caller) String() string {
+	return fmt.Sprintf("%s:%d", c.File, c.Line)
+}
+
+// Mutation is a change of StatusDescriptionMap or MethodDescriptionMap.
+// Code is set for status codes, Method for methods. Existed tells whether
+// Old holds a previous description.
+type Mutation struct {
+	Kind    MutationKind `json:"kind"`
+	Code    StatusCode   `json:"code,omitempty"`
+	Method  Method       `json:"method,omitempty"`
+	Old     Description  `json:"old,omitempty"`
+	New     Description  `json:"new,omitempty"`
+	Existed bool         `json:"existed"`
+	Caller  Caller       `json:"caller"`
+	Time    time.Time    `json:"time"`
+}
+
+// String returns a one line summary of the mutation.
+//
+// Example:
+//
+//	register 599 "" -> "My Custom Error" at main.go:12
+func (m Mutation) String() string {
+	target := fmt.Sprint(int(m.Code))
+	if m.Method != "" {
+		target = string(m.Method)
+	}
+	return fmt.Sprintf("%s %s %q -> %q at %s", m.Kind, target, m.Old, m.New, m.Caller)
+}
+
+// hooks holds the registered observers and the audit log.
+var hooks struct {
+	sync.RWMutex
+	nextID   int
+	register map[int]func(Mutation)
+	delete   map[int]func(Mutation)
+	audit    []Mutation
+	auditCap int
+}
+
+// pkgPath is the import path of this package, used to find the caller of a mutation.
+var pkgPath = reflect.TypeOf(Mutation{}).PkgPath()
+
+// Hook Funcs
+// --------------------------------------------------------------------
+
+// OnRegister calls fn after every status code or method registration,
+// including catalog loads. It returns a function removing the hook.
+//
+// Example:
+//
+//	remove := codes.OnRegister(func(m codes.Mutation) {
+//	    if m.Existed {
+//	        log.Printf("%d re-registered by %s", m.Code, m.Caller)
+//	    }
+//	})
+//	defer remove()
+func OnRegister(fn func(Mutation)) (remove func()) {
+	return addHook(&hooks.register, fn)
+}
+
+// OnDelete calls fn after every status code or method deletion,
+// including catalog removals. It returns a function removing the hook.
+func OnDelete(fn func(Mutation)) (remove func()) {
+	return addHook(&hooks.delete, fn)
+}
+
+// EnableAuditLog records the last size mutations in memory, in the order they
+// were applied to the registry. Calling it again resizes the log, keeping the
+// most recent entries. A size of zero or less disables the log, see
+// DisableAuditLog.
+func EnableAuditLog(size int) {
+	if size <= 0 {
+		DisableAuditLog()
+		return
+	}
+
+	hooks.Lock()
+	defer hooks.Unlock()
+	hooks.auditCap = size
+	if len(hooks.audit) > size {
+		hooks.audit = append([]Mutation(nil), hooks.audit[len(hooks.audit)-size:]...)
+	}
+}
+
+// DisableAuditLog stops recording mutations and clears the log.
+func DisableAuditLog() {
+	hooks.Lock()
+	defer hooks.Unlock()
+	hooks.auditCap = 0
+	hooks.audit = nil
+}
+
+// AuditLog returns a copy of the recorded mutations, oldest first.
+func AuditLog() []Mutation {
+	hooks.RLock()
+	defer hooks.RUnlock()
+	return append([]Mutation(nil), hooks.audit...)
+}
+
+// addHook stores fn in the hook set and returns its remover.
+func addHook(set *map[int]func(Mutation), fn func(Mutation)) func() {
+	hooks.Lock()
+	defer hooks.Unlock()
+	if *set == nil {
+		*set = map[int]func(Mutation){}
+	}
+	hooks.nextID++
+	id := hooks.nextID
+	(*set)[id] = fn
+
+	return func() {
+		hooks.Lock()
+		defer hooks.Unlock()
+		delete(*set, id)
+	}
+}
+
+// recordMutations stamps the mutations with the time and caller and records
+// them in the audit log. mu must be held, so the log follows the order of the
+// registry. It returns the function calling the hooks, which must be called
+// once mu is released. Hooks of concurrent mutations may run in any order.
+func recordMutations(muts ...Mutation) (notify func()) {
+	if len(muts) == 0 {
+		return func() {}
+	}
+
+	hooks.Lock()
+	if len(hooks.register) == 0 && len(hooks.delete) == 0 && hooks.auditCap == 0 {
+		hooks.Unlock()
+		return func() {}
+	}
+
+	caller := mutationCaller()
+	now := time.Now()
+	for i := range muts {
+		muts[i].Caller = caller
+		muts[i].Time = now
+	}
+
+	if hooks.auditCap > 0 {
+		hooks.audit = append(hooks.audit, muts...)
+		if over := len(hooks.audit) - hooks.auditCap; over > 0 {
+			hooks.audit = append([]Mutation(nil), hooks.audit[over:]...)
+		}
+	}
+
+	onRegister := sortedHooks(hooks.register)
+	onDelete := sortedHooks(hooks.delete)
+	hooks.Unlock()
+
+	return func() {
+		for _, m := range muts {
+			fns := onRegister
+			if m.Kind == MutationDelete {
+				fns = onDelete
+			}
+			for _, fn := range fns {
+				fn(m)
+			}
+		}
+	}
+}
+
+// sortedHooks returns the hooks of a set in registration order.
+func sortedHooks(set map[int]func(Mutation)) []func(Mutation) {
+	ids := make([]int, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	fns := make([]func(Mutation), len(ids))
+	for i, id := range ids {
+		fns[i] = set[id]
+	}
+	return fns
+}
+
+// mutationCaller returns the first frame outside this package and the
+// runtime, or the outermost frame of this package for internal goroutines
+// such as a CatalogWatcher.
+func mutationCaller() Caller {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var last Caller
+	for {
+		frame, more := frames.Next()
+		switch {
+		case strings.HasPrefix(frame.Function, pkgPath+"."):
+			last = Caller{Function: frame.Function, File: frame.File, Line: frame.Line}
+		case !strings.HasPrefix(frame.Function, "runtime."):
+			return Caller{Function: frame.Function, File: frame.File, Line: frame.Line}
+		}
+		if !more {
+			return last
+		}
+	}
+}
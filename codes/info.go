@@ -0,0 +1,129 @@
+package codes
+
+import "sort"
+
+// Semantics
+// --------------------------------------------------------------------
+
+// cacheableStatusCodes are the status codes heuristically cacheable by
+// default, as listed in RFC 9110, Section 15.1.
+var cacheableStatusCodes = map[StatusCode]bool{
+	OK:                   true,
+	NonAuthoritativeInfo: true,
+	NoContent:            true,
+	PartialContent:       true,
+	MultipleChoices:      true,
+	MovedPermanently:     true,
+	PermanentRedirect:    true,
+	NotFound:             true,
+	MethodNotAllowed:     true,
+	Gone:                 true,
+	URITooLong:           true,
+	NotImplemented:       true,
+}
+
+// retryableStatusCodes are the status codes a client may safely retry,
+// usually after a delay.
+var retryableStatusCodes = map[StatusCode]bool{
+	RequestTimeout:      true,
+	TooEarly:            true,
+	TooManyRequests:     true,
+	InternalServerError: true,
+	BadGateway:          true,
+	ServiceUnavailable:  true,
+	GatewayTimeout:      true,
+}
+
+// IsCacheable checks if a response with the status code is cacheable by default.
+func IsCacheable(code StatusCode) bool {
+	return cacheableStatusCodes[code]
+}
+
+// IsRetryable checks if a request that failed with the status code may be retried.
+// Retryable codes are 408, 425, 429, 500, 502, 503 and 504.
+func IsRetryable(code StatusCode) bool {
+	return retryableStatusCodes[code]
+}
+
+// RetryableStatusCodes returns the retryable status codes, sorted by code.
+func RetryableStatusCodes() []StatusCode {
+	out := make([]StatusCode, 0, len(retryableStatusCodes))
+	for code := range retryableStatusCodes {
+		out = append(out, code)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// GetStatusReference returns the specification defining the status code, or "" if unknown.
+func GetStatusReference(code StatusCode) string {
+	return StatusReferenceMap[code]
+}
+
+// GetMethodReference returns the specification defining the method, or "" if unknown.
+func GetMethodReference(method Method) string {
+	return MethodReferenceMap[method]
+}
+
+// Info Types
+// --------------------------------------------------------------------
+
+// StatusInfo gathers everything the package knows about a status code.
+type StatusInfo struct {
+	Code        StatusCode  `json:"code"`
+	Phrase      string      `json:"phrase"`
+	Description Description `json:"description"`
+	Class       Class       `json:"class"`
+	Reference   string      `json:"reference,omitempty"`
+	Cacheable   bool        `json:"cacheable"`
+	Retryable   bool        `json:"retryable"`
+}
+
+// MethodInfo gathers everything the package knows about a method.
+type MethodInfo struct {
+	Method      Method      `json:"method"`
+	Description Description `json:"description"`
+	Safe        bool        `json:"safe"`
+	Idempotent  bool        `json:"idempotent"`
+	Reference   string      `json:"reference,omitempty"`
+}
+
+// LookupStatus returns the information of a registered status code.
+// The boolean is false if the code is not registered.
+func LookupStatus(code StatusCode) (StatusInfo, bool) {
+	unlock := rlock()
+	desc, exists := StatusDescriptionMap[code]
+	unlock()
+
+	if !exists {
+		return StatusInfo{}, false
+	}
+	return StatusInfo{
+		Code:        code,
+		Phrase:      GetStatusPhrase(code),
+		Description: desc,
+		Class:       code.Class(),
+		Reference:   GetStatusReference(code),
+		Cacheable:   IsCacheable(code),
+		Retryable:   IsRetryable(code),
+	}, true
+}
+
+// LookupMethod returns the information of a registered method.
+// The boolean is false if the method is not registered.
+func LookupMethod(method Method) (MethodInfo, bool) {
+	unlock := rlock()
+	desc, exists := MethodDescriptionMap[method]
+	unlock()
+
+	if !exists {
+		return MethodInfo{}, false
+	}
+	return MethodInfo{
+		Method:      method,
+		Description: desc,
+		Safe:        IsSafeMethod(method),
+		Idempotent:  IsIdempotentMethod(method),
+		Reference:   GetMethodReference(method),
+	}, true
+}
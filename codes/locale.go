@@ -0,0 +1,458 @@
+package codes
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// Locale Types
+// --------------------------------------------------------------------
+
+// DefaultLocale is the language of the built-in phrases and descriptions.
+var DefaultLocale = language.English
+
+// Bundle holds the translated phrases and descriptions of one language.
+//
+// Example file:
+//
+//	language: pt-BR
+//	status_codes:
+//	  404:
+//	    phrase: Não Encontrado
+//	    description: O recurso solicitado não foi encontrado
+type Bundle struct {
+	Language     language.Tag
+	Phrases      map[StatusCode]string
+	Descriptions map[StatusCode]Description
+}
+
+// ErrInvalidBundle is wrapped by the CatalogError of an invalid bundle.
+var ErrInvalidBundle = errors.New("invalid translation bundle")
+
+// localizedPhraseMap holds the translated reason phrases by language.
+var localizedPhraseMap = map[language.Tag]map[StatusCode]string{}
+
+// localizedDescriptionMap holds the translated descriptions by language.
+var localizedDescriptionMap = map[language.Tag]map[StatusCode]Description{}
+
+// localeFallbackMap holds the fallbacks set by SetLocaleFallback.
+var localeFallbackMap = map[language.Tag][]language.Tag{}
+
+// Bundle Funcs
+// --------------------------------------------------------------------
+
+// RegisterBundle adds the translations of a bundle, replacing existing
+// translations of the same codes. Returns ErrSealed after Seal.
+func RegisterBundle(b *Bundle) error {
+	mu.Lock()
+	if sealed.Load() {
+		mu.Unlock()
+		return sealedError()
+	}
+	defer mu.Unlock()
+
+	for code, phrase := range b.Phrases {
+		setLocalized(localizedPhraseMap, b.Language, code, phrase)
+	}
+	for code, desc := range b.Descriptions {
+		setLocalized(localizedDescriptionMap, b.Language, code, desc)
+	}
+	return nil
+}
+
+// ParseBundle reads and validates a JSON or YAML translation bundle without
+// registering it. Validation errors are *CatalogError values holding the line
+// of the problem.
+func ParseBundle(r io.Reader) (*Bundle, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, &CatalogError{Line: yamlErrorLine(err), Err: fmt.Errorf("%w: %v", ErrInvalidBundle, err)}
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, &CatalogError{Line: 1, Err: fmt.Errorf("%w: expected a mapping at the top level", ErrInvalidBundle)}
+	}
+
+	doc := root.Content[0]
+	b := &Bundle{
+		Phrases:      map[StatusCode]string{},
+		Descriptions: map[StatusCode]Description{},
+	}
+	hasLanguage := false
+
+	for i := 0; i < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		switch key.Value {
+		case "language":
+			tag, err := language.Parse(value.Value)
+			if err != nil || value.Kind != yaml.ScalarNode {
+				return nil, bundleErr(value, "invalid language tag %q", value.Value)
+			}
+			b.Language = tag
+			hasLanguage = true
+		case "status_codes":
+			if err := parseBundleStatuses(b, value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, bundleErr(key, "unknown field %q", key.Value)
+		}
+	}
+
+	if !hasLanguage {
+		return nil, bundleErr(doc, "language is required")
+	}
+	return b, nil
+}
+
+// ParseBundleFile reads and validates the bundle at path without registering it.
+func ParseBundleFile(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	b, err := ParseBundle(f)
+	var cerr *CatalogError
+	if errors.As(err, &cerr) {
+		cerr.File = path
+	}
+	return b, err
+}
+
+// LoadBundle reads, validates and registers a JSON or YAML translation bundle.
+func LoadBundle(r io.Reader) (*Bundle, error) {
+	b, err := ParseBundle(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := RegisterBundle(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// LoadBundleFile reads, validates and registers the bundle at path.
+//
+// Example:
+//
+//	for _, path := range []string{"i18n/pt-BR.yaml", "i18n/es.yaml"} {
+//	    if _, err := codes.LoadBundleFile(path); err != nil {
+//	        log.Fatal(err)
+//	    }
+//	}
+func LoadBundleFile(path string) (*Bundle, error) {
+	b, err := ParseBundleFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := RegisterBundle(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+//go:embed i18n/*.yaml
+var builtinBundleFiles embed.FS
+
+// BuiltinBundles returns the translation bundles shipped with the package,
+// sorted by language: German, Spanish, Japanese and Portuguese. They translate
+// the phrase and description of every built-in status code.
+func BuiltinBundles() ([]*Bundle, error) {
+	entries, err := fs.ReadDir(builtinBundleFiles, "i18n")
+	if err != nil {
+		return nil, err
+	}
+
+	bundles := make([]*Bundle, 0, len(entries))
+	for _, entry := range entries {
+		name := path.Join("i18n", entry.Name())
+		f, err := builtinBundleFiles.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		b, err := ParseBundle(f)
+		f.Close()
+		var cerr *CatalogError
+		if errors.As(err, &cerr) {
+			cerr.File = name
+		}
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, b)
+	}
+	return bundles, nil
+}
+
+// RegisterBuiltinBundles registers the bundles of BuiltinBundles, replacing
+// existing translations of the same codes. Returns ErrSealed after Seal.
+//
+// Example:
+//
+//	if err := codes.RegisterBuiltinBundles(); err != nil {
+//	    log.Fatal(err)
+//	}
+//	codes.GetStatusPhraseLocale(codes.NotFound, language.Japanese) // 見つかりません
+func RegisterBuiltinBundles() error {
+	bundles, err := BuiltinBundles()
+	if err != nil {
+		return err
+	}
+	for _, b := range bundles {
+		if err := RegisterBundle(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseBundleStatuses decodes the status_codes mapping of a bundle.
+func parseBundleStatuses(b *Bundle, node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return bundleErr(node, "status_codes must be a mapping of codes")
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		n, err := strconv.Atoi(key.Value)
+		if err != nil || n < 100 || n > 999 {
+			return bundleErr(key, "invalid status code %q", key.Value)
+		}
+		code := StatusCode(n)
+		if _, dup := b.Phrases[code]; dup {
+			return bundleErr(key, "status code %d already defined", n)
+		}
+		if _, dup := b.Descriptions[code]; dup {
+			return bundleErr(key, "status code %d already defined", n)
+		}
+
+		if value.Kind != yaml.MappingNode {
+			return bundleErr(value, "status code %d must be a mapping", n)
+		}
+		for j := 0; j < len(value.Content); j += 2 {
+			field, text := value.Content[j], value.Content[j+1]
+			if strings.TrimSpace(text.Value) == "" {
+				return bundleErr(text, "empty %s for status code %d", field.Value, n)
+			}
+			switch field.Value {
+			case "phrase":
+				b.Phrases[code] = strings.TrimSpace(text.Value)
+			case "description":
+				b.Descriptions[code] = Description(strings.TrimSpace(text.Value))
+			default:
+				return bundleErr(field, "unknown field %q", field.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// bundleErr builds a CatalogError wrapping ErrInvalidBundle at the line of node.
+func bundleErr(node *yaml.Node, format string, args ...interface{}) error {
+	return &CatalogError{
+		Line: node.Line,
+		Err:  fmt.Errorf("%w: %s", ErrInvalidBundle, fmt.Sprintf(format, args...)),
+	}
+}
+
+// Locale Funcs
+// --------------------------------------------------------------------
+
+// SetLocaleFallback sets the languages tried after tag and its parents, in
+// order. The default locale is always tried last. Returns ErrSealed after Seal.
+//
+// Example:
+//
+//	// Portuguese speakers from Portugal get Brazilian texts before English
+//	codes.SetLocaleFallback(language.EuropeanPortuguese, language.BrazilianPortuguese)
+func SetLocaleFallback(tag language.Tag, fallbacks ...language.Tag) error {
+	mu.Lock()
+	if sealed.Load() {
+		mu.Unlock()
+		return sealedError()
+	}
+	defer mu.Unlock()
+
+	if len(fallbacks) == 0 {
+		delete(localeFallbackMap, tag)
+		return nil
+	}
+	localeFallbackMap[tag] = append([]language.Tag(nil), fallbacks...)
+	return nil
+}
+
+// LocaleChain returns the languages tried for tag, in order: tag, its
+// parents ("pt-BR", then "pt"), the fallbacks of each, and the default locale.
+func LocaleChain(tag language.Tag) []language.Tag {
+	defer rlock()()
+	return localeChainLocked(tag)
+}
+
+// localeChainLocked builds the chain of LocaleChain. mu must be held or the registry sealed.
+func localeChainLocked(tag language.Tag) []language.Tag {
+	var chain []language.Tag
+	seen := map[language.Tag]bool{}
+
+	var add func(t language.Tag)
+	add = func(t language.Tag) {
+		for ; t != language.Und && !seen[t]; t = t.Parent() {
+			seen[t] = true
+			chain = append(chain, t)
+			for _, f := range localeFallbackMap[t] {
+				add(f)
+			}
+		}
+	}
+	add(tag)
+	add(DefaultLocale)
+	return chain
+}
+
+// SupportedLocales returns the default locale followed by every language
+// with translations, sorted by tag.
+func SupportedLocales() []language.Tag {
+	defer rlock()()
+	return supportedLocalesLocked()
+}
+
+// supportedLocalesLocked builds the list of SupportedLocales. mu must be held or the registry sealed.
+func supportedLocalesLocked() []language.Tag {
+	seen := map[language.Tag]bool{DefaultLocale: true}
+	var tags []language.Tag
+	for tag, m := range localizedPhraseMap {
+		if len(m) > 0 && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	for tag, m := range localizedDescriptionMap {
+		if len(m) > 0 && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].String() < tags[j].String() })
+	return append([]language.Tag{DefaultLocale}, tags...)
+}
+
+// GetStatusInfoLocale returns the description of the status code in the
+// first language of LocaleChain(tag) with a translation, or GetStatusInfo.
+//
+// Example:
+//
+//	codes.GetStatusInfoLocale(codes.NotFound, language.MustParse("pt-BR"))
+func GetStatusInfoLocale(code StatusCode, tag language.Tag) string {
+	desc, _ := lookupLocale(localizedDescriptionMap, code, tag)
+	if desc == "" {
+		return GetStatusInfo(code)
+	}
+	return string(desc)
+}
+
+// GetStatusPhraseLocale returns the reason phrase of the status code in the
+// first language of LocaleChain(tag) with a translation, or GetStatusPhrase.
+func GetStatusPhraseLocale(code StatusCode, tag language.Tag) string {
+	phrase, _ := lookupLocale(localizedPhraseMap, code, tag)
+	if phrase == "" {
+		return GetStatusPhrase(code)
+	}
+	return phrase
+}
+
+// lookupLocale returns the first translation of code along the chain of tag,
+// and the language it was found in, or DefaultLocale.
+func lookupLocale[V any](m map[language.Tag]map[StatusCode]V, code StatusCode, tag language.Tag) (V, language.Tag) {
+	defer rlock()()
+	for _, t := range localeChainLocked(tag) {
+		if v, exists := m[t][code]; exists {
+			return v, t
+		}
+	}
+	var zero V
+	return zero, DefaultLocale
+}
+
+// localizedDescription returns the translated description of code in tag, without fallbacks.
+func localizedDescription(code StatusCode, tag language.Tag) Description {
+	defer rlock()()
+	return localizedDescriptionMap[tag][code]
+}
+
+// setLocalized stores a translation. mu must be held.
+func setLocalized[V any](m map[language.Tag]map[StatusCode]V, tag language.Tag, code StatusCode, v V) {
+	if m[tag] == nil {
+		m[tag] = map[StatusCode]V{}
+	}
+	m[tag][code] = v
+}
+
+// Locale HTTP
+// --------------------------------------------------------------------
+
+// MatchLocale returns the supported locale that best matches an
+// Accept-Language header, or DefaultLocale.
+func MatchLocale(acceptLanguage string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+
+	supported := SupportedLocales()
+	_, index, confidence := language.NewMatcher(supported).Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	return supported[index]
+}
+
+// RequestLocale returns the supported locale matching the Accept-Language
+// header of the request, or DefaultLocale.
+func RequestLocale(r *http.Request) language.Tag {
+	return MatchLocale(r.Header.Get("Accept-Language"))
+}
+
+// LocalizedError replies to the request with the status code, its phrase and
+// its end-user message (see Message) in the language selected by the
+// Accept-Language header. The phrase follows the language of the message,
+// which is sent as Content-Language. Like http.Error, it does not end the request.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    codes.LocalizedError(w, r, codes.NotFound)
+//	}
+//
+// Output with "Accept-Language: pt-BR":
+//
+//	404 Não Encontrado
+//	Não encontramos a página que você procurava.
+func LocalizedError(w http.ResponseWriter, r *http.Request, code StatusCode) {
+	msg, tag := requestMessage(r, code)
+
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "text/plain; charset=utf-8")
+	h.Set("Content-Language", tag.String())
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Add("Vary", "Accept-Language")
+	w.WriteHeader(int(code))
+	fmt.Fprintf(w, "%d %s\n%s\n", int(code), GetStatusPhraseLocale(code, tag), msg)
+}
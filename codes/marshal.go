@@ -0,0 +1,230 @@
+package codes
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Marshalling Errors
+// --------------------------------------------------------------------
+
+// ErrUnknownStatusCode is returned when a decoded status code is not in StatusDescriptionMap.
+var ErrUnknownStatusCode = errors.New("unknown status code")
+
+// ErrUnknownMethod is returned when a decoded method is not in MethodDescriptionMap.
+var ErrUnknownMethod = errors.New("unknown method")
+
+// ErrEmptyDescription is returned when a decoded description is empty.
+var ErrEmptyDescription = errors.New("empty description")
+
+// jsonNull is left untouched on decode, as encoding/json does for built-in types.
+var jsonNull = []byte("null")
+
+// Parse Funcs
+// --------------------------------------------------------------------
+
+// ParseStatusCode parses a status code from its number ("404") or its
+// reason phrase ("Not Found", case-insensitive) and checks it against
+// the registered status codes.
+//
+// Example:
+//
+//	code, err := codes.ParseStatusCode("Not Found")
+//	fmt.Println(int(code)) // Output: 404
+func ParseStatusCode(s string) (StatusCode, error) {
+	s = strings.TrimSpace(s)
+
+	defer rlock()()
+
+	if n, err := strconv.Atoi(s); err == nil {
+		code := StatusCode(n)
+		if _, exists := StatusDescriptionMap[code]; !exists {
+			return 0, fmt.Errorf("%w: %d", ErrUnknownStatusCode, n)
+		}
+		return code, nil
+	}
+
+	for code, phrase := range StatusPhraseMap {
+		if strings.EqualFold(phrase, s) {
+			if _, exists := StatusDescriptionMap[code]; exists {
+				return code, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownStatusCode, s)
+}
+
+// ParseMethod parses a method name and checks it against the registered methods.
+// Method names are case-sensitive, as defined by RFC 9110.
+func ParseMethod(s string) (Method, error) {
+	method := Method(strings.TrimSpace(s))
+
+	defer rlock()()
+
+	if _, exists := MethodDescriptionMap[method]; !exists {
+		return "", fmt.Errorf("%w: %q", ErrUnknownMethod, s)
+	}
+	return method, nil
+}
+
+// StatusCode Marshalling
+// --------------------------------------------------------------------
+
+// MarshalText encodes the status code as its decimal number.
+func (sc StatusCode) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(int(sc))), nil
+}
+
+// UnmarshalText decodes a status code from its number or reason phrase.
+// See ParseStatusCode.
+func (sc *StatusCode) UnmarshalText(text []byte) error {
+	code, err := ParseStatusCode(string(text))
+	if err != nil {
+		return err
+	}
+	*sc = code
+	return nil
+}
+
+// MarshalJSON encodes the status code as a JSON number.
+func (sc StatusCode) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(sc))), nil
+}
+
+// UnmarshalJSON decodes a status code from a JSON number (404) or a JSON
+// string holding either the number ("404") or the reason phrase ("Not Found").
+func (sc *StatusCode) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, jsonNull) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return sc.UnmarshalText([]byte(s))
+	}
+
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("%w: %s", ErrUnknownStatusCode, data)
+	}
+	return sc.UnmarshalText([]byte(strconv.Itoa(n)))
+}
+
+// MarshalYAML encodes the status code as a YAML integer.
+func (sc StatusCode) MarshalYAML() (interface{}, error) {
+	return int(sc), nil
+}
+
+// UnmarshalYAML decodes a status code from a YAML integer or string.
+func (sc *StatusCode) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return sc.UnmarshalText([]byte(s))
+}
+
+// Method Marshalling
+// --------------------------------------------------------------------
+
+// MarshalText encodes the method as its name.
+func (m Method) MarshalText() ([]byte, error) {
+	return []byte(m), nil
+}
+
+// UnmarshalText decodes a method name and checks it against the registered methods.
+func (m *Method) UnmarshalText(text []byte) error {
+	method, err := ParseMethod(string(text))
+	if err != nil {
+		return err
+	}
+	*m = method
+	return nil
+}
+
+// MarshalJSON encodes the method as a JSON string.
+func (m Method) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(m))
+}
+
+// UnmarshalJSON decodes a method from a JSON string.
+func (m *Method) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), jsonNull) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %s", ErrUnknownMethod, data)
+	}
+	return m.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML encodes the method as a YAML string.
+func (m Method) MarshalYAML() (interface{}, error) {
+	return string(m), nil
+}
+
+// UnmarshalYAML decodes a method from a YAML string.
+func (m *Method) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return m.UnmarshalText([]byte(s))
+}
+
+// Description Marshalling
+// --------------------------------------------------------------------
+
+// MarshalText encodes the description as plain text.
+func (c Description) MarshalText() ([]byte, error) {
+	return []byte(c), nil
+}
+
+// UnmarshalText decodes a description, rejecting empty values.
+func (c *Description) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" {
+		return ErrEmptyDescription
+	}
+	*c = Description(s)
+	return nil
+}
+
+// MarshalJSON encodes the description as a JSON string.
+func (c Description) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(c))
+}
+
+// UnmarshalJSON decodes a description from a JSON string, rejecting empty values.
+func (c *Description) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), jsonNull) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML encodes the description as a YAML string.
+func (c Description) MarshalYAML() (interface{}, error) {
+	return string(c), nil
+}
+
+// UnmarshalYAML decodes a description from a YAML string, rejecting empty values.
+func (c *Description) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(s))
+}
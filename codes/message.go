@@ -0,0 +1,265 @@
+package codes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"text/template"
+
+	"golang.org/x/text/language"
+)
+
+// Message Types
+// --------------------------------------------------------------------
+
+// MessageData is the data of a message template. Request fields are empty
+// when there is no request.
+//
+// Example template:
+//
+//	We couldn't find {{.Path}}. Reference: {{.RequestID}}
+type MessageData struct {
+	Code        StatusCode
+	Phrase      string
+	Description string
+	Class       Class
+	Locale      language.Tag
+
+	Method    string
+	Host      string
+	Path      string
+	Query     string
+	RequestID string
+
+	// Values holds application fields, for example {{.Values.support_email}}.
+	Values map[string]string
+}
+
+// ErrInvalidMessage is returned for templates that do not parse or execute.
+var ErrInvalidMessage = errors.New("invalid message template")
+
+// messageKey identifies a template by status code or, with a zero code, by class.
+type messageKey struct {
+	code  StatusCode
+	class Class
+}
+
+// messages holds the templates by language. It is separate from the code
+// registry, so messages can still be overridden after Seal.
+var messages = struct {
+	sync.RWMutex
+	m map[language.Tag]map[messageKey]*template.Template
+}{m: map[language.Tag]map[messageKey]*template.Template{}}
+
+// defaultClassMessages are the built-in messages of each class.
+var defaultClassMessages = map[Class]string{
+	Informational: "Your request is being processed.",
+	Success:       "Your request was completed successfully.",
+	Redirection:   "The page you are looking for has moved.",
+	ClientError:   "There was a problem with your request.",
+	ServerError:   "Something went wrong on our side. Please try again later.",
+}
+
+// defaultMessages are the built-in messages of common status codes.
+var defaultMessages = map[StatusCode]string{
+	BadRequest:           "We couldn't understand your request. Please check it and try again.",
+	Unauthorized:         "Please sign in to continue.",
+	Forbidden:            "You don't have permission to access this page.",
+	NotFound:             "We couldn't find the page you were looking for.",
+	MethodNotAllowed:     "This action isn't available here.",
+	RequestTimeout:       "Your request took too long. Please try again.",
+	Conflict:             "Your request conflicts with a recent change. Please refresh and try again.",
+	Gone:                 "This page is no longer available.",
+	PayloadTooLarge:      "What you sent is too large.",
+	UnsupportedMediaType: "This file type isn't supported.",
+	TooManyRequests:      "You're going a little too fast. Please wait a moment and try again.",
+	InternalServerError:  "Something went wrong on our side. We're looking into it.",
+	BadGateway:           "We're having trouble reaching one of our services. Please try again shortly.",
+	ServiceUnavailable:   "We're temporarily unavailable. Please try again in a few minutes.",
+	GatewayTimeout:       "One of our services took too long to respond. Please try again shortly.",
+}
+
+func init() {
+	ResetMessages()
+}
+
+// Message Funcs
+// --------------------------------------------------------------------
+
+// RegisterMessage sets the end-user message template of a status code in the
+// given languages, or in DefaultLocale if none is given. An empty text removes
+// the template. Templates use text/template with MessageData.
+//
+// Example:
+//
+//	codes.RegisterMessage(codes.NotFound, "We couldn't find {{.Path}}.")
+//	codes.RegisterMessage(codes.NotFound, "Não encontramos {{.Path}}.", language.BrazilianPortuguese)
+func RegisterMessage(code StatusCode, text string, tags ...language.Tag) error {
+	return registerMessage(messageKey{code: code}, fmt.Sprint(int(code)), text, tags)
+}
+
+// RegisterClassMessage sets the message template used by the status codes of
+// a class that have no template of their own.
+func RegisterClassMessage(class Class, text string, tags ...language.Tag) error {
+	if _, exists := classNames[class]; !exists {
+		return fmt.Errorf("%w: %d", ErrUnknownClass, int(class))
+	}
+	return registerMessage(messageKey{class: class}, class.String(), text, tags)
+}
+
+// ResetMessages removes every registered message and restores the built-in
+// English messages.
+func ResetMessages() {
+	messages.Lock()
+	messages.m = map[language.Tag]map[messageKey]*template.Template{}
+	messages.Unlock()
+
+	for class, text := range defaultClassMessages {
+		if err := RegisterClassMessage(class, text); err != nil {
+			panic(err)
+		}
+	}
+	for code, text := range defaultMessages {
+		if err := RegisterMessage(code, text); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Message returns the end-user message of data.Code. The first language of
+// LocaleChain(data.Locale) with a template for the code or its class, or a
+// translated description, wins. A code template comes before a class
+// template. Missing Phrase, Description and Class fields are filled in.
+func Message(data MessageData) (string, error) {
+	msg, _, err := message(completeMessageData(data))
+	return msg, err
+}
+
+// NewMessageData returns the MessageData of a response to r, in the language
+// of its Accept-Language header. The request ID is read from the
+// X-Request-Id header. r may be nil.
+func NewMessageData(r *http.Request, code StatusCode) MessageData {
+	data := MessageData{Code: code, Locale: DefaultLocale}
+	if r != nil {
+		data.Locale = RequestLocale(r)
+		data.Method = r.Method
+		data.Host = r.Host
+		data.RequestID = r.Header.Get("X-Request-Id")
+		if r.URL != nil {
+			data.Path = r.URL.Path
+			data.Query = r.URL.RawQuery
+		}
+	}
+	return completeMessageData(data)
+}
+
+// RequestMessage returns the end-user message of a response to r.
+// Templates that fail to execute fall back to the localized description.
+func RequestMessage(r *http.Request, code StatusCode) string {
+	msg, _ := requestMessage(r, code)
+	return msg
+}
+
+// requestMessage returns the message of a response to r and its language.
+func requestMessage(r *http.Request, code StatusCode) (string, language.Tag) {
+	return safeMessage(NewMessageData(r, code))
+}
+
+// safeMessage returns the message of a complete MessageData and its language,
+// falling back to the localized description when the template fails.
+func safeMessage(data MessageData) (string, language.Tag) {
+	msg, tag, err := message(data)
+	if err != nil {
+		desc, tag := lookupLocale(localizedDescriptionMap, data.Code, data.Locale)
+		if desc == "" {
+			return data.Description, DefaultLocale
+		}
+		return string(desc), tag
+	}
+	return msg, tag
+}
+
+// message executes the template of a complete MessageData and returns the
+// language it was found in. A translated description in an earlier language
+// of the chain wins over a template, so users get their own language first.
+func message(data MessageData) (string, language.Tag, error) {
+	chain := LocaleChain(data.Locale)
+
+	messages.RLock()
+	var tmpl *template.Template
+	found := DefaultLocale
+	for _, tag := range chain {
+		m := messages.m[tag]
+		if tmpl = m[messageKey{code: data.Code}]; tmpl == nil {
+			tmpl = m[messageKey{class: data.Class}]
+		}
+		if tmpl != nil {
+			found = tag
+			break
+		}
+		if desc := localizedDescription(data.Code, tag); desc != "" {
+			messages.RUnlock()
+			return string(desc), tag, nil
+		}
+	}
+	messages.RUnlock()
+
+	if tmpl == nil {
+		return data.Description, found, nil
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", found, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	return b.String(), found, nil
+}
+
+// registerMessage parses and stores a template under key.
+func registerMessage(key messageKey, name, text string, tags []language.Tag) error {
+	if len(tags) == 0 {
+		tags = []language.Tag{DefaultLocale}
+	}
+
+	var tmpl *template.Template
+	if text != "" {
+		var err error
+		tmpl, err = template.New(name).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+		}
+	}
+
+	messages.Lock()
+	defer messages.Unlock()
+	for _, tag := range tags {
+		if tmpl == nil {
+			delete(messages.m[tag], key)
+			continue
+		}
+		if messages.m[tag] == nil {
+			messages.m[tag] = map[messageKey]*template.Template{}
+		}
+		messages.m[tag][key] = tmpl
+	}
+	return nil
+}
+
+// completeMessageData fills the fields derived from the status code.
+func completeMessageData(data MessageData) MessageData {
+	if data.Locale == language.Und {
+		data.Locale = DefaultLocale
+	}
+	if data.Phrase == "" {
+		data.Phrase = GetStatusPhraseLocale(data.Code, data.Locale)
+	}
+	if data.Description == "" {
+		data.Description = GetStatusInfoLocale(data.Code, data.Locale)
+	}
+	if data.Class == UnknownClass {
+		data.Class = data.Code.Class()
+	}
+	return data
+}
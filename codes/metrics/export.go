@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Export Types
+// --------------------------------------------------------------------
+
+// Content types of the exposition formats.
+const (
+	PrometheusContentType  = "text/plain; version=0.0.4; charset=utf-8"
+	OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// labelEscaper escapes label values.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Export Funcs
+// --------------------------------------------------------------------
+
+// Handler serves the metrics in the OpenMetrics format when the Accept header
+// asks for it, and in the Prometheus text format otherwise. The response is
+// aborted with http.ErrAbortHandler if writing it fails.
+//
+// Example:
+//
+//	mux.Handle("/metrics", collector.Handler())
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		openMetrics := strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text")
+		if openMetrics {
+			w.Header().Set("Content-Type", OpenMetricsContentType)
+		} else {
+			w.Header().Set("Content-Type", PrometheusContentType)
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Add("Vary", "Accept")
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		write := c.WritePrometheus
+		if openMetrics {
+			write = c.WriteOpenMetrics
+		}
+		// Abort on write errors, so a scraper never reads a truncated
+		// exposition as a complete one
+		if err := write(w); err != nil {
+			panic(http.ErrAbortHandler)
+		}
+	})
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format.
+func (c *Collector) WritePrometheus(w io.Writer) error {
+	return c.write(w, false)
+}
+
+// WriteOpenMetrics writes the metrics in the OpenMetrics text format.
+func (c *Collector) WriteOpenMetrics(w io.Writer) error {
+	return c.write(w, true)
+}
+
+// write writes every metric family, in the OpenMetrics format when openMetrics is set.
+func (c *Collector) write(out io.Writer, openMetrics bool) error {
+	responses, latencies := c.snapshot()
+	w := bufio.NewWriter(out)
+
+	// In OpenMetrics, counter families are named without the _total suffix.
+	responsesName := c.namespace + "_responses"
+	family := responsesName
+	if !openMetrics {
+		family += "_total"
+	}
+	w.WriteString("# HELP " + family + " Responses by method, route, status code and class.\n")
+	w.WriteString("# TYPE " + family + " counter\n")
+	for _, s := range responses {
+		w.WriteString(responsesName + "_total")
+		writeLabels(w,
+			"method", s.key.method,
+			"route", s.key.route,
+			"code", strconv.Itoa(int(s.key.code)),
+			"class", s.key.code.Class().String(),
+		)
+		w.WriteString(" " + strconv.FormatUint(s.count, 10) + "\n")
+	}
+
+	latencyName := c.namespace + "_request_duration_seconds"
+	w.WriteString("# HELP " + latencyName + " Response latency by method, route and class.\n")
+	w.WriteString("# TYPE " + latencyName + " histogram\n")
+	if openMetrics {
+		w.WriteString("# UNIT " + latencyName + " seconds\n")
+	}
+	for _, s := range latencies {
+		labels := []string{"method", s.key.method, "route", s.key.route, "class", s.key.class.String()}
+		for i, upper := range c.buckets {
+			w.WriteString(latencyName + "_bucket")
+			writeLabels(w, append(labels, "le", formatFloat(upper))...)
+			w.WriteString(" " + strconv.FormatUint(s.counts[i], 10) + "\n")
+		}
+		w.WriteString(latencyName + "_bucket")
+		writeLabels(w, append(labels, "le", "+Inf")...)
+		w.WriteString(" " + strconv.FormatUint(s.count, 10) + "\n")
+
+		w.WriteString(latencyName + "_sum")
+		writeLabels(w, labels...)
+		w.WriteString(" " + formatFloat(s.sum) + "\n")
+		w.WriteString(latencyName + "_count")
+		writeLabels(w, labels...)
+		w.WriteString(" " + strconv.FormatUint(s.count, 10) + "\n")
+	}
+
+	if openMetrics {
+		w.WriteString("# EOF\n")
+	}
+	return w.Flush()
+}
+
+// writeLabels writes {name="value",...} from name and value pairs.
+func writeLabels(w *bufio.Writer, pairs ...string) {
+	w.WriteByte('{')
+	for i := 0; i < len(pairs); i += 2 {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.WriteString(pairs[i] + `="` + labelEscaper.Replace(pairs[i+1]) + `"`)
+	}
+	w.WriteByte('}')
+}
+
+// formatFloat formats a sample value or bucket bound.
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,405 @@
+// Package metrics collects response counts by status code, class, method and
+// route, and latency histograms, and exposes them in the Prometheus text and
+// OpenMetrics formats without the Prometheus client library.
+//
+// Example:
+//
+//	collector, err := metrics.NewCollector(metrics.Options{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	mux.Handle("/orders/", collector.Handle("/orders/{id}", ordersHandler))
+//	mux.Handle("/metrics", collector.Handler())
+//
+// Exposed metrics, with the default "http" namespace:
+//
+//	http_responses_total{method, route, code, class}
+//	http_request_duration_seconds{method, route, class}
+package metrics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+)
+
+// Collector Types
+// --------------------------------------------------------------------
+
+// Options configures a Collector. Zero fields use the defaults.
+type Options struct {
+	// Namespace prefixes the metric names, "http" by default.
+	Namespace string
+	// Buckets are the upper bounds of the latency histogram in seconds,
+	// DefaultBuckets by default.
+	Buckets []float64
+	// Route returns the route label of a request handled by Middleware.
+	// The default labels every request "unmatched", as request paths would
+	// create a time series per path. Keep the number of routes bounded.
+	Route func(r *http.Request) string
+}
+
+// Collector counts responses and their latency. It is safe for concurrent use.
+type Collector struct {
+	namespace string
+	buckets   []float64
+	route     func(r *http.Request) string
+
+	mu         sync.Mutex
+	responses  map[responseKey]uint64
+	histograms map[latencyKey]*histogram
+}
+
+// responseKey identifies a response counter.
+type responseKey struct {
+	method string
+	route  string
+	code   codes.StatusCode
+}
+
+// latencyKey identifies a latency histogram.
+type latencyKey struct {
+	method string
+	route  string
+	class  codes.Class
+}
+
+// histogram holds the cumulative counts of a latency histogram.
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// DefaultBuckets are the default latency buckets in seconds, the same as the
+// Prometheus client libraries.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// ErrInvalidOptions is returned for invalid namespaces or buckets.
+var ErrInvalidOptions = errors.New("invalid metrics options")
+
+// metricName matches valid Prometheus metric names.
+var metricName = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// otherMethod is the method label of unregistered methods, bounding the label values.
+const otherMethod = "OTHER"
+
+// unmatchedRoute is the route label of Middleware without Options.Route.
+const unmatchedRoute = "unmatched"
+
+// Collector Funcs
+// --------------------------------------------------------------------
+
+// NewCollector returns an empty Collector.
+func NewCollector(opts Options) (*Collector, error) {
+	if opts.Namespace == "" {
+		opts.Namespace = "http"
+	}
+	if !metricName.MatchString(opts.Namespace) {
+		return nil, fmt.Errorf("%w: namespace %q", ErrInvalidOptions, opts.Namespace)
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = DefaultBuckets
+	}
+	for i, b := range opts.Buckets {
+		if b <= 0 || (i > 0 && b <= opts.Buckets[i-1]) {
+			return nil, fmt.Errorf("%w: buckets must be positive and increasing", ErrInvalidOptions)
+		}
+	}
+	if opts.Route == nil {
+		opts.Route = func(r *http.Request) string { return unmatchedRoute }
+	}
+
+	return &Collector{
+		namespace:  opts.Namespace,
+		buckets:    append([]float64(nil), opts.Buckets...),
+		route:      opts.Route,
+		responses:  map[responseKey]uint64{},
+		histograms: map[latencyKey]*histogram{},
+	}, nil
+}
+
+// Observe records a response. Unregistered methods are recorded as "OTHER".
+//
+// Example:
+//
+//	start := time.Now()
+//	resp, err := client.Do(req)
+//	if err == nil {
+//	    collector.Observe(codes.Method(req.Method), "orders-api", codes.StatusCode(resp.StatusCode), time.Since(start))
+//	}
+func (c *Collector) Observe(method codes.Method, route string, code codes.StatusCode, d time.Duration) {
+	m := methodLabel(method)
+	seconds := d.Seconds()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses[responseKey{method: m, route: route, code: code}]++
+
+	key := latencyKey{method: m, route: route, class: code.Class()}
+	h, exists := c.histograms[key]
+	if !exists {
+		h = &histogram{counts: make([]uint64, len(c.buckets))}
+		c.histograms[key] = h
+	}
+	for i, upper := range c.buckets {
+		if seconds <= upper {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// Count returns the number of responses recorded for a method, route and
+// status code. Unregistered methods count the "OTHER" responses, as in Observe.
+func (c *Collector) Count(method codes.Method, route string, code codes.StatusCode) uint64 {
+	key := responseKey{method: methodLabel(method), route: route, code: code}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.responses[key]
+}
+
+// ClassCount returns the number of responses recorded in a class, for every
+// method and route.
+func (c *Collector) ClassCount(class codes.Class) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var n uint64
+	for key, count := range c.responses {
+		if key.code.Class() == class {
+			n += count
+		}
+	}
+	return n
+}
+
+// Reset clears every metric.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responses = map[responseKey]uint64{}
+	c.histograms = map[latencyKey]*histogram{}
+}
+
+// Middleware records the responses of next, with the route given by Options.Route.
+func (c *Collector) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.serve(c.route(r), next, w, r)
+	})
+}
+
+// Handle records the responses of next with a fixed route label.
+//
+// Example:
+//
+//	mux.Handle("/orders/", collector.Handle("/orders/{id}", ordersHandler))
+func (c *Collector) Handle(route string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.serve(route, next, w, r)
+	})
+}
+
+// serve calls next and records its response.
+func (c *Collector) serve(route string, next http.Handler, w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w}
+	next.ServeHTTP(rec.wrap(), r)
+	code := rec.code
+	if code == 0 {
+		code = codes.OK
+	}
+	c.Observe(codes.Method(r.Method), route, code, time.Since(start))
+}
+
+// methodLabel returns the method label of a method, "OTHER" for unregistered ones.
+func methodLabel(method codes.Method) string {
+	if parsed, err := codes.ParseMethod(string(method)); err == nil {
+		return string(parsed)
+	}
+	return otherMethod
+}
+
+// Snapshot Funcs
+// --------------------------------------------------------------------
+
+// responseSample is a response counter of a snapshot.
+type responseSample struct {
+	key   responseKey
+	count uint64
+}
+
+// latencySample is a latency histogram of a snapshot.
+type latencySample struct {
+	key latencyKey
+	histogram
+}
+
+// snapshot returns copies of the metrics, sorted by labels.
+func (c *Collector) snapshot() ([]responseSample, []latencySample) {
+	c.mu.Lock()
+	responses := make([]responseSample, 0, len(c.responses))
+	for key, count := range c.responses {
+		responses = append(responses, responseSample{key: key, count: count})
+	}
+	latencies := make([]latencySample, 0, len(c.histograms))
+	for key, h := range c.histograms {
+		cp := *h
+		cp.counts = append([]uint64(nil), h.counts...)
+		latencies = append(latencies, latencySample{key: key, histogram: cp})
+	}
+	c.mu.Unlock()
+
+	sort.Slice(responses, func(i, j int) bool {
+		a, b := responses[i].key, responses[j].key
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.code < b.code
+	})
+	sort.Slice(latencies, func(i, j int) bool {
+		a, b := latencies[i].key, latencies[j].key
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.class < b.class
+	})
+	return responses, latencies
+}
+
+// Status Recorder
+// --------------------------------------------------------------------
+
+// statusRecorder records the final status code written to a ResponseWriter.
+type statusRecorder struct {
+	http.ResponseWriter
+	code codes.StatusCode
+}
+
+// WriteHeader records final status codes, skipping informational responses
+// such as 103 Early Hints.
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.code == 0 && (code >= 200 || code == int(codes.SwitchingProtocols)) {
+		r.code = codes.StatusCode(code)
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Write records an implicit 200 OK.
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.code == 0 {
+		r.code = codes.OK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// flush flushes the underlying http.Flusher.
+func (r *statusRecorder) flush() {
+	if r.code == 0 {
+		r.code = codes.OK
+	}
+	r.ResponseWriter.(http.Flusher).Flush()
+}
+
+// hijack hijacks the connection of the underlying http.Hijacker.
+// A connection hijacked before any status code, such as a WebSocket upgrade,
+// records 101 Switching Protocols.
+func (r *statusRecorder) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := r.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil && r.code == 0 {
+		r.code = codes.SwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// push initiates an HTTP/2 server push on the underlying http.Pusher.
+func (r *statusRecorder) push(target string, opts *http.PushOptions) error {
+	return r.ResponseWriter.(http.Pusher).Push(target, opts)
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// wrap returns the recorder as a ResponseWriter implementing http.Flusher,
+// http.Hijacker and http.Pusher only when the underlying writer does, so
+// handlers checking for them with a type assertion see the truth.
+func (r *statusRecorder) wrap() http.ResponseWriter {
+	_, f := r.ResponseWriter.(http.Flusher)
+	_, h := r.ResponseWriter.(http.Hijacker)
+	_, p := r.ResponseWriter.(http.Pusher)
+
+	switch {
+	case f && h && p:
+		return struct {
+			*statusRecorder
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{r, flushFunc(r.flush), hijackFunc(r.hijack), pushFunc(r.push)}
+	case f && h:
+		return struct {
+			*statusRecorder
+			http.Flusher
+			http.Hijacker
+		}{r, flushFunc(r.flush), hijackFunc(r.hijack)}
+	case f && p:
+		return struct {
+			*statusRecorder
+			http.Flusher
+			http.Pusher
+		}{r, flushFunc(r.flush), pushFunc(r.push)}
+	case h && p:
+		return struct {
+			*statusRecorder
+			http.Hijacker
+			http.Pusher
+		}{r, hijackFunc(r.hijack), pushFunc(r.push)}
+	case f:
+		return struct {
+			*statusRecorder
+			http.Flusher
+		}{r, flushFunc(r.flush)}
+	case h:
+		return struct {
+			*statusRecorder
+			http.Hijacker
+		}{r, hijackFunc(r.hijack)}
+	case p:
+		return struct {
+			*statusRecorder
+			http.Pusher
+		}{r, pushFunc(r.push)}
+	}
+	return r
+}
+
+// flushFunc implements http.Flusher with a function.
+type flushFunc func()
+
+func (f flushFunc) Flush() { f() }
+
+// hijackFunc implements http.Hijacker with a function.
+type hijackFunc func() (net.Conn, *bufio.ReadWriter, error)
+
+func (f hijackFunc) Hijack() (net.Conn, *bufio.ReadWriter, error) { return f() }
+
+// pushFunc implements http.Pusher with a function.
+type pushFunc func(target string, opts *http.PushOptions) error
+
+func (f pushFunc) Push(target string, opts *http.PushOptions) error { return f(target, opts) }
@@ -0,0 +1,228 @@
+package codes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Problem Types
+// --------------------------------------------------------------------
+
+// ProblemContentType is the media type of problem details, as defined by RFC 9457.
+const ProblemContentType = "application/problem+json"
+
+// Problem is a problem details object, as defined by RFC 9457. Extension
+// members are kept in Extensions and marshalled at the top level.
+//
+// Example:
+//
+//	p := codes.NewProblem(codes.NotFound, "Order 42 does not exist")
+//	p.Extensions = map[string]interface{}{"order": 42}
+//	json.NewEncoder(w).Encode(p)
+//	// {"type":"about:blank","title":"Not Found","status":404,"detail":"Order 42 does not exist","order":42}
+type Problem struct {
+	Type       string                 `json:"type,omitempty"`
+	Title      string                 `json:"title,omitempty"`
+	Status     StatusCode             `json:"status,omitempty"`
+	Detail     string                 `json:"detail,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// HTTPError is an error carrying the status code to reply with. Message is
+// safe to show to clients, Err is the internal cause and never shown.
+//
+// Example:
+//
+//	if err := db.Find(id); err != nil {
+//	    return codes.NewHTTPError(codes.NotFound, "Order not found", err)
+//	}
+type HTTPError struct {
+	Code    StatusCode
+	Message string
+	Err     error
+	// Problem holds extra problem details for the client, it may be nil.
+	Problem *Problem
+}
+
+// problemMembers are the standard members of a Problem.
+var problemMembers = map[string]bool{"type": true, "title": true, "status": true, "detail": true, "instance": true}
+
+// Problem Funcs
+// --------------------------------------------------------------------
+
+// NewProblem returns a Problem of type "about:blank" titled with the reason phrase of code.
+func NewProblem(code StatusCode, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  GetStatusPhrase(code),
+		Status: code,
+		Detail: detail,
+	}
+}
+
+// Error returns "status title: detail".
+func (p *Problem) Error() string {
+	msg := fmt.Sprintf("%d %s", int(p.Status), p.Title)
+	if p.Detail != "" {
+		msg += ": " + p.Detail
+	}
+	return msg
+}
+
+// StatusCode returns the status of the problem, or 500 when it is not set
+// or outside 100-599.
+func (p *Problem) StatusCode() StatusCode {
+	return statusOrInternal(p.Status)
+}
+
+// MarshalJSON encodes the problem with its extension members at the top level.
+// Extensions never override standard members.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type plain Problem
+	std, err := json.Marshal(plain(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return std, err
+	}
+
+	keys := make([]string, 0, len(p.Extensions))
+	for k := range p.Extensions {
+		if !problemMembers[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	out := std[:len(std)-1]
+	for _, k := range keys {
+		key, _ := json.Marshal(k)
+		value, err := json.Marshal(p.Extensions[k])
+		if err != nil {
+			return nil, err
+		}
+		if len(out) > 1 {
+			out = append(out, ',')
+		}
+		out = append(out, key...)
+		out = append(out, ':')
+		out = append(out, value...)
+	}
+	return append(out, '}'), nil
+}
+
+// UnmarshalJSON decodes a problem, keeping unknown members in Extensions.
+// Standard members of the wrong type are ignored, as required by RFC 9457.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	*p = Problem{}
+	for k, raw := range members {
+		switch k {
+		case "type":
+			_ = json.Unmarshal(raw, &p.Type)
+		case "title":
+			_ = json.Unmarshal(raw, &p.Title)
+		case "detail":
+			_ = json.Unmarshal(raw, &p.Detail)
+		case "instance":
+			_ = json.Unmarshal(raw, &p.Instance)
+		case "status":
+			var n int
+			if json.Unmarshal(raw, &n) == nil {
+				p.Status = StatusCode(n)
+			}
+		default:
+			var v interface{}
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return err
+			}
+			if p.Extensions == nil {
+				p.Extensions = map[string]interface{}{}
+			}
+			p.Extensions[k] = v
+		}
+	}
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+	return nil
+}
+
+// HTTPError Funcs
+// --------------------------------------------------------------------
+
+// NewHTTPError returns an HTTPError. err may be nil.
+func NewHTTPError(code StatusCode, message string, err error) *HTTPError {
+	return &HTTPError{Code: code, Message: message, Err: err}
+}
+
+// Error returns "code phrase: message: cause", skipping empty parts.
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("%d %s", int(e.Code), GetStatusPhrase(e.Code))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the internal cause.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the status code of the error, or 500 when it is
+// outside 100-599.
+func (e *HTTPError) StatusCode() StatusCode {
+	return statusOrInternal(e.Code)
+}
+
+// ToProblem returns the problem details shown to clients. The internal cause is left out.
+func (e *HTTPError) ToProblem() *Problem {
+	p := NewProblem(e.StatusCode(), e.Message)
+	if e.Problem != nil {
+		extra := *e.Problem
+		if extra.Type != "" {
+			p.Type = extra.Type
+		}
+		if extra.Title != "" {
+			p.Title = extra.Title
+		}
+		if extra.Detail != "" {
+			p.Detail = extra.Detail
+		}
+		p.Instance = extra.Instance
+		p.Extensions = extra.Extensions
+	}
+	return p
+}
+
+// ProblemOf returns the problem details of err for clients: a *Problem or the
+// problem of an *HTTPError found in its chain. The boolean is false otherwise.
+func ProblemOf(err error) (*Problem, bool) {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.ToProblem(), true
+	}
+	var problem *Problem
+	if errors.As(err, &problem) {
+		return problem, true
+	}
+	return nil, false
+}
+
+// statusOrInternal returns code, or 500 when it cannot be written as a
+// response status.
+func statusOrInternal(code StatusCode) StatusCode {
+	if code < 100 || code > 599 {
+		return InternalServerError
+	}
+	return code
+}
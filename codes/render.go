@@ -0,0 +1,369 @@
+package codes
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Render Types
+// --------------------------------------------------------------------
+
+// Format is an output format for Render and RenderMethods.
+type Format int
+
+// Output Formats
+const (
+	FormatText Format = iota
+	FormatMarkdown
+	FormatCSV
+	FormatJSON
+	FormatHTML
+)
+
+// Order is the order rows are rendered in.
+type Order int
+
+// Orders
+const (
+	// OrderByCode sorts status codes by number and methods by name.
+	OrderByCode Order = iota
+	// OrderByClass sorts status codes by class, then by reason phrase.
+	// Methods are sorted by safety, then idempotency, then name.
+	OrderByClass
+	// OrderByName sorts status codes by reason phrase and methods by name.
+	OrderByName
+)
+
+// Table is the format independent data handed to a Renderer.
+// Cells hold native values (int, bool, string) so encoders can keep their types.
+type Table struct {
+	Headers []string
+	Rows    [][]interface{}
+}
+
+// Renderer writes a Table to w in a given format.
+type Renderer func(w io.Writer, t *Table) error
+
+// RenderOptions configures Render and RenderMethods.
+type RenderOptions struct {
+	// Order of the rows. Defaults to OrderByCode.
+	Order Order
+	// StatusCodes to render. Defaults to StatusDescriptionMap.
+	StatusCodes map[StatusCode]Description
+	// Methods to render. Defaults to MethodDescriptionMap.
+	Methods map[Method]Description
+}
+
+// ErrUnknownFormat is returned when no renderer is registered for a format.
+var ErrUnknownFormat = errors.New("unknown render format")
+
+// renderersMu guards renderers, apart from the registry lock.
+var renderersMu sync.RWMutex
+
+var renderers = map[Format]Renderer{
+	FormatText:     renderText,
+	FormatMarkdown: renderMarkdown,
+	FormatCSV:      renderCSV,
+	FormatJSON:     renderJSON,
+	FormatHTML:     renderHTML,
+}
+
+// RegisterRenderer registers a renderer for a format, replacing any existing one.
+//
+// Example:
+//
+//	const FormatTSV codes.Format = 100
+//	codes.RegisterRenderer(FormatTSV, myTSVRenderer)
+func RegisterRenderer(format Format, r Renderer) {
+	renderersMu.Lock()
+	renderers[format] = r
+	renderersMu.Unlock()
+}
+
+// renderer returns the renderer registered for a format.
+func renderer(format Format) (Renderer, bool) {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	r, exists := renderers[format]
+	return r, exists
+}
+
+// Sort Funcs
+// --------------------------------------------------------------------
+
+// SortedStatusCodes returns the keys of m in the given order.
+func SortedStatusCodes(m map[StatusCode]Description, order Order) []StatusCode {
+	defer rlock()()
+	return sortedStatusCodesLocked(m, order)
+}
+
+// sortedStatusCodesLocked sorts like SortedStatusCodes. mu must be held or the registry sealed.
+func sortedStatusCodesLocked(m map[StatusCode]Description, order Order) []StatusCode {
+	keys := make([]StatusCode, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch order {
+		case OrderByClass:
+			if a.Class() != b.Class() {
+				return a.Class() < b.Class()
+			}
+			fallthrough
+		case OrderByName:
+			if pa, pb := statusPhraseLocked(a), statusPhraseLocked(b); pa != pb {
+				return pa < pb
+			}
+		}
+		return a < b
+	})
+	return keys
+}
+
+// SortedMethods returns the keys of m in the given order.
+func SortedMethods(m map[Method]Description, order Order) []Method {
+	defer rlock()()
+	return sortedMethodsLocked(m, order)
+}
+
+// sortedMethodsLocked sorts like SortedMethods. mu must be held or the registry sealed.
+func sortedMethodsLocked(m map[Method]Description, order Order) []Method {
+	keys := make([]Method, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if order == OrderByClass {
+			if sa, sb := safeMethods[a], safeMethods[b]; sa != sb {
+				return sa
+			}
+			if ia, ib := idempotentMethods[a], idempotentMethods[b]; ia != ib {
+				return ia
+			}
+		}
+		return a < b
+	})
+	return keys
+}
+
+// Render Funcs
+// --------------------------------------------------------------------
+
+// Render writes the status codes as a table in the given format.
+// Columns are Code, Phrase, Class and Description. A nil opts uses the defaults.
+//
+// Example:
+//
+//	codes.Render(os.Stdout, codes.FormatMarkdown, &codes.RenderOptions{Order: codes.OrderByClass})
+func Render(w io.Writer, format Format, opts *RenderOptions) error {
+	if opts == nil {
+		opts = &RenderOptions{}
+	}
+
+	m := opts.StatusCodes
+	unlock := rlock()
+	if m == nil {
+		m = StatusDescriptionMap
+	}
+	keys := sortedStatusCodesLocked(m, opts.Order)
+	t := &Table{
+		Headers: []string{"Code", "Phrase", "Class", "Description"},
+		Rows:    make([][]interface{}, 0, len(keys)),
+	}
+	for _, k := range keys {
+		t.Rows = append(t.Rows, []interface{}{int(k), statusPhraseLocked(k), k.Class().String(), string(m[k])})
+	}
+	unlock()
+
+	r, exists := renderer(format)
+	if !exists {
+		return fmt.Errorf("%w: %d", ErrUnknownFormat, format)
+	}
+	return r(w, t)
+}
+
+// RenderMethods writes the methods as a table in the given format.
+// Columns are Method, Safe, Idempotent and Description. A nil opts uses the defaults.
+func RenderMethods(w io.Writer, format Format, opts *RenderOptions) error {
+	if opts == nil {
+		opts = &RenderOptions{}
+	}
+
+	m := opts.Methods
+	unlock := rlock()
+	if m == nil {
+		m = MethodDescriptionMap
+	}
+	keys := sortedMethodsLocked(m, opts.Order)
+	t := &Table{
+		Headers: []string{"Method", "Safe", "Idempotent", "Description"},
+		Rows:    make([][]interface{}, 0, len(keys)),
+	}
+	for _, k := range keys {
+		t.Rows = append(t.Rows, []interface{}{string(k), safeMethods[k], idempotentMethods[k], string(m[k])})
+	}
+	unlock()
+
+	r, exists := renderer(format)
+	if !exists {
+		return fmt.Errorf("%w: %d", ErrUnknownFormat, format)
+	}
+	return r(w, t)
+}
+
+// Renderers
+// --------------------------------------------------------------------
+
+// cells returns the rows of t as strings.
+func (t *Table) cells() [][]string {
+	out := make([][]string, len(t.Rows))
+	for i, row := range t.Rows {
+		out[i] = make([]string, len(row))
+		for j, v := range row {
+			out[i][j] = fmt.Sprint(v)
+		}
+	}
+	return out
+}
+
+// renderText writes an aligned plain text table.
+func renderText(w io.Writer, t *Table) error {
+	rows := t.cells()
+	widths := make([]int, len(t.Headers))
+	for i, h := range t.Headers {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, c := range row {
+			if len(c) > widths[i] {
+				widths[i] = len(c)
+			}
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		for i, c := range row {
+			if i == len(row)-1 {
+				sb.WriteString(c)
+				break
+			}
+			sb.WriteString(c)
+			sb.WriteString(strings.Repeat(" ", widths[i]-len(c)+2))
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(t.Headers)
+	sep := make([]string, len(t.Headers))
+	for i := range sep {
+		sep[i] = strings.Repeat("-", widths[i])
+	}
+	writeRow(sep)
+	for _, row := range rows {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// renderMarkdown writes a GitHub flavoured Markdown table.
+func renderMarkdown(w io.Writer, t *Table) error {
+	escape := strings.NewReplacer("|", "\\|", "\n", " ")
+
+	var sb strings.Builder
+	sb.WriteString("| " + strings.Join(t.Headers, " | ") + " |\n")
+	sb.WriteString("|")
+	for range t.Headers {
+		sb.WriteString("------|")
+	}
+	sb.WriteString("\n")
+	for _, row := range t.cells() {
+		for i := range row {
+			row[i] = escape.Replace(row[i])
+		}
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// renderCSV writes a CSV table with a header row.
+func renderCSV(w io.Writer, t *Table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.cells()); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// renderJSON writes a JSON array of objects keyed by the lowercase headers,
+// keeping the column order and the native cell types.
+func renderJSON(w io.Writer, t *Table) error {
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i, row := range t.Rows {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n  {")
+		for j, v := range row {
+			if j > 0 {
+				sb.WriteString(",")
+			}
+			key, _ := json.Marshal(strings.ToLower(t.Headers[j]))
+			val, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			sb.Write(key)
+			sb.WriteString(":")
+			sb.Write(val)
+		}
+		sb.WriteString("}")
+	}
+	if len(t.Rows) > 0 {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("]\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// renderHTML writes an HTML table.
+func renderHTML(w io.Writer, t *Table) error {
+	var sb strings.Builder
+	sb.WriteString("<table>\n  <thead>\n    <tr>")
+	for _, h := range t.Headers {
+		sb.WriteString("<th>" + html.EscapeString(h) + "</th>")
+	}
+	sb.WriteString("</tr>\n  </thead>\n  <tbody>\n")
+	for _, row := range t.cells() {
+		sb.WriteString("    <tr>")
+		for _, c := range row {
+			sb.WriteString("<td>" + html.EscapeString(c) + "</td>")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("  </tbody>\n</table>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,174 @@
+package codes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Response Types
+// --------------------------------------------------------------------
+
+// ResponseError is returned by CheckResponse for responses with an
+// unexpected status code. It matches ErrUnexpectedStatus and its Problem
+// with errors.Is and errors.As.
+type ResponseError struct {
+	Code   StatusCode
+	Method string
+	URL    string
+	// Problem holds the decoded problem details of the body, it may be nil.
+	Problem *Problem
+	// Body holds the body when it is not problem details, up to ResponseBodyLimit bytes.
+	Body   []byte
+	Header http.Header
+}
+
+// ResponseBodyLimit is the maximum number of bytes read from the body of
+// an unexpected response.
+var ResponseBodyLimit int64 = 64 << 10
+
+// ErrUnexpectedStatus is matched by every ResponseError.
+var ErrUnexpectedStatus = errors.New("unexpected status code")
+
+// ErrNilResponse is returned by CheckResponse for a nil response.
+var ErrNilResponse = errors.New("nil response")
+
+// Response Funcs
+// --------------------------------------------------------------------
+
+// CheckResponse returns nil when the status code of resp is one of expected,
+// or any 2xx code when expected is empty. Otherwise it reads the body, up to
+// ResponseBodyLimit bytes, closes it and returns a *ResponseError holding
+// the decoded problem details, if any.
+//
+// Example:
+//
+//	resp, err := http.Get(url)
+//	if err != nil {
+//	    return err
+//	}
+//	defer resp.Body.Close()
+//	if err := codes.CheckResponse(resp, codes.OK, codes.NotModified); err != nil {
+//	    return err // GET https://api.example.com/orders/42: 404 Not Found: Order 42 does not exist
+//	}
+func CheckResponse(resp *http.Response, expected ...StatusCode) error {
+	return checkResponse(resp, func(code StatusCode) bool {
+		if len(expected) == 0 {
+			return code.Class() == Success
+		}
+		for _, e := range expected {
+			if code == e {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// CheckResponseClass is CheckResponse for status classes. Without classes,
+// it accepts the Success class, like CheckResponse.
+//
+// Example:
+//
+//	err := codes.CheckResponseClass(resp, codes.Success, codes.Redirection)
+func CheckResponseClass(resp *http.Response, classes ...Class) error {
+	if len(classes) == 0 {
+		classes = []Class{Success}
+	}
+	return checkResponse(resp, func(code StatusCode) bool {
+		for _, c := range classes {
+			if c.Contains(code) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Error returns "method url: code phrase: detail", skipping empty parts.
+func (e *ResponseError) Error() string {
+	var b strings.Builder
+	if e.Method != "" || e.URL != "" {
+		b.WriteString(strings.TrimSpace(e.Method + " " + e.URL))
+		b.WriteString(": ")
+	}
+	phrase := GetStatusPhrase(e.Code)
+	fmt.Fprintf(&b, "%d %s", int(e.Code), phrase)
+	if p := e.Problem; p != nil {
+		if p.Title != "" && p.Title != phrase {
+			b.WriteString(": " + p.Title)
+		}
+		if p.Detail != "" {
+			b.WriteString(": " + p.Detail)
+		}
+	}
+	return b.String()
+}
+
+// StatusCode returns the status code of the response.
+func (e *ResponseError) StatusCode() StatusCode {
+	return e.Code
+}
+
+// Unwrap returns ErrUnexpectedStatus and the problem details, if any.
+func (e *ResponseError) Unwrap() []error {
+	if e.Problem == nil {
+		return []error{ErrUnexpectedStatus}
+	}
+	return []error{ErrUnexpectedStatus, e.Problem}
+}
+
+// checkResponse returns a *ResponseError when ok rejects the status code of resp.
+func checkResponse(resp *http.Response, ok func(StatusCode) bool) error {
+	if resp == nil {
+		return ErrNilResponse
+	}
+	code := StatusCode(resp.StatusCode)
+	if ok(code) {
+		return nil
+	}
+
+	respErr := &ResponseError{Code: code, Header: resp.Header}
+	if req := resp.Request; req != nil {
+		respErr.Method = req.Method
+		if req.URL != nil {
+			respErr.URL = req.URL.Redacted()
+		}
+	}
+
+	if resp.Body != nil {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, ResponseBodyLimit))
+		resp.Body.Close()
+		if problem := decodeProblem(resp.Header.Get("Content-Type"), body); problem != nil {
+			respErr.Problem = problem
+		} else {
+			respErr.Body = body
+		}
+	}
+	return respErr
+}
+
+// decodeProblem decodes problem details from a problem+json body, or from a
+// JSON body holding at least one standard member. It returns nil otherwise.
+func decodeProblem(contentType string, body []byte) *Problem {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || len(body) == 0 {
+		return nil
+	}
+	if mediaType != ProblemContentType && mediaType != "application/json" {
+		return nil
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return nil
+	}
+	if mediaType == "application/json" && problem.Title == "" && problem.Status == 0 && problem.Detail == "" && problem.Type == "about:blank" {
+		return nil
+	}
+	return &problem
+}
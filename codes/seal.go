@@ -0,0 +1,57 @@
+package codes
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// Seal
+// --------------------------------------------------------------------
+
+// ErrSealed is returned by registry mutations after Seal.
+var ErrSealed = errors.New("registry is sealed")
+
+// sealed is set once by Seal and never cleared.
+var sealed atomic.Bool
+
+// Seal freezes the registry. Afterwards, registrations, deletions and catalog
+// loads return ErrSealed, or panic when built with the codesdebug tag, and
+// lookups no longer take the registry lock. Seal cannot be undone.
+//
+// Example:
+//
+//	func main() {
+//	    codes.RegisterStatusCode(599, "My Custom Error")
+//	    codes.Seal()
+//
+//	    err := codes.RegisterStatusCode(598, "Too late") // ErrSealed
+//	}
+func Seal() {
+	mu.Lock()
+	sealed.Store(true)
+	mu.Unlock()
+}
+
+// IsSealed checks if Seal was called.
+func IsSealed() bool {
+	return sealed.Load()
+}
+
+// sealedError returns ErrSealed, or panics in debug builds. Callers must
+// release mu first.
+func sealedError() error {
+	if panicOnSealed {
+		panic(ErrSealed)
+	}
+	return ErrSealed
+}
+
+// rlock read-locks the registry and returns the matching unlock. Once sealed
+// the registry never changes, so no lock is taken.
+func rlock() (unlock func()) {
+	if sealed.Load() {
+		return func() {}
+	}
+	mu.RLock()
+	return mu.RUnlock
+}
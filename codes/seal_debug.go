@@ -0,0 +1,6 @@
+//go:build codesdebug
+
+package codes
+
+// panicOnSealed makes mutations of a sealed registry panic in debug builds.
+const panicOnSealed = true
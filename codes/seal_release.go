@@ -0,0 +1,6 @@
+//go:build !codesdebug
+
+package codes
+
+// panicOnSealed makes mutations of a sealed registry return ErrSealed.
+const panicOnSealed = false
@@ -0,0 +1,294 @@
+package codes
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Search Types
+// --------------------------------------------------------------------
+
+// MatchKind tells whether a Match is a status code or a method.
+type MatchKind int
+
+// Match Kinds
+const (
+	MatchStatusCode MatchKind = iota
+	MatchMethod
+)
+
+// Match is a single Search result. Code is set for status codes, Method for methods.
+// Name holds the reason phrase of a status code or the name of a method.
+type Match struct {
+	Kind        MatchKind   `json:"kind"`
+	Code        StatusCode  `json:"code,omitempty"`
+	Method      Method      `json:"method,omitempty"`
+	Name        string      `json:"name"`
+	Description Description `json:"description"`
+	// Score ranks the match from 0 (excluded) to 100 (exact).
+	Score float64 `json:"score"`
+}
+
+// String returns "status" or "method".
+func (k MatchKind) String() string {
+	if k == MatchMethod {
+		return "method"
+	}
+	return "status"
+}
+
+// MarshalText encodes the kind as its String form.
+func (k MatchKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Scores
+const (
+	scoreExact    = 100
+	scorePhrase   = 90
+	scorePrefix   = 80
+	scoreWildcard = 70
+	// scoreText is the maximum score of a token match over phrases and descriptions.
+	scoreText = 60
+)
+
+// Search Funcs
+// --------------------------------------------------------------------
+
+// Search ranks the registered status codes and methods against query and
+// returns the matches, best first.
+//
+// Supported queries:
+//   - Numbers and prefixes: "404", "50"
+//   - Wildcards, where x, X, * or ? stand for one digit: "4xx", "5?3"
+//   - Phrases and method names: "Not Found", "PATCH"
+//   - Tokens over phrases and descriptions, with prefix and typo-tolerant
+//     matching: "auth", "timout"
+//
+// Example:
+//
+//	for _, m := range codes.Search("timeout") {
+//	    fmt.Printf("%d %s (%.0f)\n", m.Code, m.Name, m.Score)
+//	}
+func Search(query string) []Match {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	unlock := rlock()
+	statuses := make(map[StatusCode]Description, len(StatusDescriptionMap))
+	for k, v := range StatusDescriptionMap {
+		statuses[k] = v
+	}
+	methods := make(map[Method]Description, len(MethodDescriptionMap))
+	for k, v := range MethodDescriptionMap {
+		methods[k] = v
+	}
+	unlock()
+
+	tokens := tokenize(query)
+	var matches []Match
+
+	for code, desc := range statuses {
+		phrase := GetStatusPhrase(code)
+		if score := scoreStatus(query, tokens, code, phrase, desc); score > 0 {
+			matches = append(matches, Match{
+				Kind:        MatchStatusCode,
+				Code:        code,
+				Name:        phrase,
+				Description: desc,
+				Score:       score,
+			})
+		}
+	}
+
+	for method, desc := range methods {
+		if score := scoreMethod(query, tokens, method, desc); score > 0 {
+			matches = append(matches, Match{
+				Kind:        MatchMethod,
+				Method:      method,
+				Name:        string(method),
+				Description: desc,
+				Score:       score,
+			})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		return a.Method < b.Method
+	})
+	return matches
+}
+
+// scoreStatus scores a status code against the query.
+func scoreStatus(query string, tokens []string, code StatusCode, phrase string, desc Description) float64 {
+	number := strconv.Itoa(int(code))
+
+	switch {
+	case query == number:
+		return scoreExact
+	case strings.EqualFold(query, phrase):
+		return scorePhrase
+	case isDigits(query) && strings.HasPrefix(number, query):
+		return scorePrefix
+	case isWildcard(query):
+		if matchWildcard(query, number) {
+			return scoreWildcard
+		}
+		return 0
+	}
+
+	return scoreTokens(tokens, tokenize(phrase), tokenize(string(desc)))
+}
+
+// scoreMethod scores a method against the query.
+func scoreMethod(query string, tokens []string, method Method, desc Description) float64 {
+	name := string(method)
+
+	switch {
+	case query == name:
+		return scoreExact
+	case strings.EqualFold(query, name):
+		return scorePhrase
+	case isDigits(query) || isWildcard(query):
+		return 0
+	}
+
+	return scoreTokens(tokens, tokenize(name), tokenize(string(desc)))
+}
+
+// scoreTokens scores query tokens against the words of a name and a
+// description. Every token must match a word, name words weigh more.
+func scoreTokens(tokens, nameWords, descWords []string) float64 {
+	if len(tokens) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, token := range tokens {
+		best := bestWordScore(token, nameWords)
+		if s := bestWordScore(token, descWords) * 0.7; s > best {
+			best = s
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	return total / float64(len(tokens)) * scoreText
+}
+
+// bestWordScore returns the best score, from 0 to 1, of token against words.
+func bestWordScore(token string, words []string) float64 {
+	var best float64
+	for _, word := range words {
+		var s float64
+		switch {
+		case word == token:
+			s = 1
+		case strings.HasPrefix(word, token):
+			s = 0.8
+		case len(token) >= 3 && strings.Contains(word, token):
+			s = 0.6
+		case len(token) >= 4:
+			maxDist := 1
+			if len(token) >= 7 {
+				maxDist = 2
+			}
+			if d := levenshtein(token, word); d <= maxDist {
+				s = 0.5 * (1 - float64(d)/float64(len(token)))
+			}
+		}
+		if s > best {
+			best = s
+		}
+	}
+	return best
+}
+
+// Utils
+// --------------------------------------------------------------------
+
+// tokenize lowercases s and splits it into letter and digit runs.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// isDigits checks if s only holds ASCII digits.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isWildcard checks if s is a three character status code pattern with at
+// least one wildcard, for example "4xx".
+func isWildcard(s string) bool {
+	if len(s) != 3 || isDigits(s) {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && !isWildcardRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWildcardRune(r rune) bool {
+	return r == 'x' || r == 'X' || r == '*' || r == '?'
+}
+
+// matchWildcard matches a wildcard pattern against a status code number.
+func matchWildcard(pattern, number string) bool {
+	if len(pattern) != len(number) {
+		return false
+	}
+	for i, r := range pattern {
+		if !isWildcardRune(r) && byte(r) != number[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,174 @@
+package codes
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogValuer Funcs
+// --------------------------------------------------------------------
+
+// LogValue implements slog.LogValuer, logging the status code as a group
+// with code, phrase, class and description attributes.
+//
+// Example:
+//
+//	slog.Info("request done", "status", codes.NotFound)
+//	// Output: ... status.code=404 status.phrase="Not Found" status.class=4xx status.description="..."
+func (sc StatusCode) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("code", int(sc)),
+		slog.String("phrase", GetStatusPhrase(sc)),
+		slog.String("class", sc.Class().String()),
+		slog.String("description", GetStatusInfo(sc)),
+	)
+}
+
+// LogValue implements slog.LogValuer, logging the method as a group with
+// name, description, safe and idempotent attributes.
+func (m Method) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", string(m)),
+		slog.String("description", GetMethodDescription(m)),
+		slog.Bool("safe", IsSafeMethod(m)),
+		slog.Bool("idempotent", IsIdempotentMethod(m)),
+	)
+}
+
+// Status Handler
+// --------------------------------------------------------------------
+
+// StatusHandlerOptions configures a StatusHandler.
+type StatusHandlerOptions struct {
+	// Levels maps status classes to the minimum level of the records carrying them.
+	// Defaults to Warn for 4xx and Error for 5xx.
+	Levels map[Class]slog.Level
+	// Color prefixes messages with an ANSI colour matching the status class.
+	Color bool
+	// StatusKey is the key of attributes holding plain integer status codes.
+	// Defaults to "status". StatusCode values are detected under any key.
+	StatusKey string
+}
+
+// StatusHandler is a slog.Handler wrapper that raises the level of records,
+// and optionally colours their message, based on the class of the status
+// code they carry.
+type StatusHandler struct {
+	next   slog.Handler
+	opts   StatusHandlerOptions
+	status StatusCode
+	max    slog.Level
+}
+
+// ANSI colours by status class
+var classColors = map[Class]string{
+	Informational: "\033[36m",
+	Success:       "\033[32m",
+	Redirection:   "\033[34m",
+	ClientError:   "\033[33m",
+	ServerError:   "\033[31m",
+}
+
+const colorReset = "\033[0m"
+
+// NewStatusHandler returns a StatusHandler wrapping next. A nil opts uses the defaults.
+//
+// Example:
+//
+//	logger := slog.New(codes.NewStatusHandler(slog.NewTextHandler(os.Stderr, nil), nil))
+//	logger.Info("request done", "status", codes.InternalServerError) // Logged at ERROR
+func NewStatusHandler(next slog.Handler, opts *StatusHandlerOptions) *StatusHandler {
+	h := &StatusHandler{next: next}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Levels == nil {
+		h.opts.Levels = map[Class]slog.Level{
+			ClientError: slog.LevelWarn,
+			ServerError: slog.LevelError,
+		}
+	}
+	if h.opts.StatusKey == "" {
+		h.opts.StatusKey = "status"
+	}
+
+	h.max = slog.LevelDebug
+	for _, level := range h.opts.Levels {
+		if level > h.max {
+			h.max = level
+		}
+	}
+	return h
+}
+
+// Enabled reports whether the wrapped handler handles records at level, or
+// at any level a status class could raise them to.
+func (h *StatusHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level) || h.next.Enabled(ctx, h.max)
+}
+
+// Handle adjusts the record level and message by status class and passes it on.
+func (h *StatusHandler) Handle(ctx context.Context, r slog.Record) error {
+	status := h.status
+	r.Attrs(func(a slog.Attr) bool {
+		if code, ok := h.statusOf(a); ok {
+			status = code
+			return false
+		}
+		return true
+	})
+
+	if status != 0 {
+		class := status.Class()
+		if level, exists := h.opts.Levels[class]; exists && level > r.Level {
+			r.Level = level
+		}
+		if color, exists := classColors[class]; h.opts.Color && exists {
+			r.Message = color + r.Message + colorReset
+		}
+	}
+
+	if !h.next.Enabled(ctx, r.Level) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a StatusHandler whose records carry attrs, remembering
+// any status code among them.
+func (h *StatusHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithAttrs(attrs)
+	for _, a := range attrs {
+		if code, ok := h.statusOf(a); ok {
+			clone.status = code
+		}
+	}
+	return &clone
+}
+
+// WithGroup returns a StatusHandler whose wrapped handler opens the group name.
+func (h *StatusHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithGroup(name)
+	return &clone
+}
+
+// statusOf extracts a status code from a StatusCode attribute or an integer
+// attribute named StatusKey.
+func (h *StatusHandler) statusOf(a slog.Attr) (StatusCode, bool) {
+	if a.Value.Kind() == slog.KindLogValuer {
+		if code, ok := a.Value.Any().(StatusCode); ok {
+			return code, true
+		}
+	}
+	if a.Key == h.opts.StatusKey {
+		switch a.Value.Kind() {
+		case slog.KindInt64:
+			return StatusCode(a.Value.Int64()), true
+		case slog.KindUint64:
+			return StatusCode(a.Value.Uint64()), true
+		}
+	}
+	return 0, false
+}
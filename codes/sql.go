@@ -0,0 +1,68 @@
+package codes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// StatusCode SQL
+// --------------------------------------------------------------------
+
+// Value implements driver.Valuer, storing the status code as an integer.
+// Unregistered status codes return ErrUnknownStatusCode.
+func (sc StatusCode) Value() (driver.Value, error) {
+	unlock := rlock()
+	_, exists := StatusDescriptionMap[sc]
+	unlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownStatusCode, int(sc))
+	}
+	return int64(sc), nil
+}
+
+// Scan implements sql.Scanner. It accepts integer columns as well as text
+// columns holding a number or a reason phrase, and returns ErrUnknownStatusCode
+// for NULL or unregistered values.
+func (sc *StatusCode) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case int64:
+		return sc.UnmarshalText([]byte(strconv.FormatInt(v, 10)))
+	case []byte:
+		return sc.UnmarshalText(v)
+	case string:
+		return sc.UnmarshalText([]byte(v))
+	case nil:
+		return fmt.Errorf("%w: NULL", ErrUnknownStatusCode)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrUnknownStatusCode, src)
+	}
+}
+
+// Method SQL
+// --------------------------------------------------------------------
+
+// Value implements driver.Valuer, storing the method as a string.
+// Unregistered methods return ErrUnknownMethod.
+func (m Method) Value() (driver.Value, error) {
+	if _, err := ParseMethod(string(m)); err != nil {
+		return nil, err
+	}
+	return string(m), nil
+}
+
+// Scan implements sql.Scanner. It accepts text columns and returns
+// ErrUnknownMethod for NULL or unregistered values.
+func (m *Method) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return m.UnmarshalText(v)
+	case string:
+		return m.UnmarshalText([]byte(v))
+	case nil:
+		return fmt.Errorf("%w: NULL", ErrUnknownMethod)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrUnknownMethod, src)
+	}
+}
@@ -0,0 +1,337 @@
+// Package sysexits maps HTTP status codes to the process exit codes of
+// sysexits.h and back, for command line tools calling HTTP APIs.
+//
+// Example:
+//
+//	resp, err := client.Do(req)
+//	if err == nil && resp.StatusCode >= 400 {
+//	    err = codes.NewHTTPError(codes.StatusCode(resp.StatusCode), "", nil)
+//	}
+//	if err != nil {
+//	    sysexits.Exit(err) // Prints "tool: 403 Forbidden" and exits with EX_NOPERM
+//	}
+//
+// Unlike gRPC codes, several HTTP codes share an exit code, so FromExit
+// returns a representative status such as 403 for EX_NOPERM. Tools with
+// conventions of their own, such as exiting with EX_USAGE on 404, call
+// SetToExit before running.
+package sysexits
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/internal/bimap"
+)
+
+// Code Types
+// --------------------------------------------------------------------
+
+// Code is a process exit code, as defined by sysexits.h.
+type Code int
+
+// Exit Codes
+const (
+	OK          Code = 0
+	Usage       Code = 64
+	DataErr     Code = 65
+	NoInput     Code = 66
+	NoUser      Code = 67
+	NoHost      Code = 68
+	Unavailable Code = 69
+	Software    Code = 70
+	OSErr       Code = 71
+	OSFile      Code = 72
+	CantCreat   Code = 73
+	IOErr       Code = 74
+	TempFail    Code = 75
+	Protocol    Code = 76
+	NoPerm      Code = 77
+	Config      Code = 78
+)
+
+// codeNames maps exit codes to their sysexits.h names.
+var codeNames = map[Code]string{
+	OK:          "EX_OK",
+	Usage:       "EX_USAGE",
+	DataErr:     "EX_DATAERR",
+	NoInput:     "EX_NOINPUT",
+	NoUser:      "EX_NOUSER",
+	NoHost:      "EX_NOHOST",
+	Unavailable: "EX_UNAVAILABLE",
+	Software:    "EX_SOFTWARE",
+	OSErr:       "EX_OSERR",
+	OSFile:      "EX_OSFILE",
+	CantCreat:   "EX_CANTCREAT",
+	IOErr:       "EX_IOERR",
+	TempFail:    "EX_TEMPFAIL",
+	Protocol:    "EX_PROTOCOL",
+	NoPerm:      "EX_NOPERM",
+	Config:      "EX_CONFIG",
+}
+
+// ErrInvalidCode is returned for exit codes not defined by sysexits.h.
+var ErrInvalidCode = errors.New("invalid exit code")
+
+// ErrInvalidStatus is returned for HTTP status codes outside 100-599.
+var ErrInvalidStatus = errors.New("invalid HTTP status code")
+
+// Mapping Types
+// --------------------------------------------------------------------
+
+// Mapper maps HTTP status codes and exit codes. Libraries shared by several
+// tools use a Mapper of their own rather than overriding Default.
+type Mapper struct {
+	mapping *bimap.Map[Code]
+}
+
+// toExitMap is the default HTTP to exit code mapping. Other codes map by class.
+var toExitMap = map[codes.StatusCode]Code{
+	codes.BadRequest:                    DataErr,
+	codes.Unauthorized:                  NoPerm,
+	codes.Forbidden:                     NoPerm,
+	codes.NotFound:                      NoInput,
+	codes.MethodNotAllowed:              Usage,
+	codes.ProxyAuthRequired:             NoPerm,
+	codes.RequestTimeout:                TempFail,
+	codes.Gone:                          NoInput,
+	codes.PayloadTooLarge:               DataErr,
+	codes.UnsupportedMediaType:          DataErr,
+	codes.UnprocessableEntity:           DataErr,
+	codes.TooManyRequests:               TempFail,
+	codes.UnavailableForLegalReasons:    NoPerm,
+	codes.InternalServerError:           Software,
+	codes.NotImplemented:                Unavailable,
+	codes.BadGateway:                    TempFail,
+	codes.ServiceUnavailable:            Unavailable,
+	codes.GatewayTimeout:                TempFail,
+	codes.HTTPVersionNotSupported:       Protocol,
+	codes.NetworkAuthenticationRequired: NoPerm,
+}
+
+// fromExitMap is the default exit code to HTTP mapping.
+var fromExitMap = map[Code]codes.StatusCode{
+	OK:          codes.OK,
+	Usage:       codes.BadRequest,
+	DataErr:     codes.BadRequest,
+	NoInput:     codes.NotFound,
+	NoUser:      codes.NotFound,
+	NoHost:      codes.BadGateway,
+	Unavailable: codes.ServiceUnavailable,
+	Software:    codes.InternalServerError,
+	OSErr:       codes.InternalServerError,
+	OSFile:      codes.InternalServerError,
+	CantCreat:   codes.InternalServerError,
+	IOErr:       codes.InternalServerError,
+	TempFail:    codes.ServiceUnavailable,
+	Protocol:    codes.BadGateway,
+	NoPerm:      codes.Forbidden,
+	Config:      codes.InternalServerError,
+}
+
+// Default is the Mapper used by the package level functions.
+var Default = NewMapper()
+
+// Code Funcs
+// --------------------------------------------------------------------
+
+// String returns the sysexits.h name of the code, for example "EX_NOPERM".
+func (c Code) String() string {
+	if name, exists := codeNames[c]; exists {
+		return name
+	}
+	return "Code(" + strconv.Itoa(int(c)) + ")"
+}
+
+// IsValid checks if the code is 0 or defined by sysexits.h.
+func (c Code) IsValid() bool {
+	_, exists := codeNames[c]
+	return exists
+}
+
+// ParseCode parses an exit code from its name ("EX_NOPERM", "noperm",
+// case-insensitive) or its number ("77").
+func ParseCode(s string) (Code, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		if c := Code(n); c.IsValid() {
+			return c, nil
+		}
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCode, s)
+	}
+
+	name := strings.ToUpper(s)
+	if !strings.HasPrefix(name, "EX_") {
+		name = "EX_" + name
+	}
+	for c, n := range codeNames {
+		if name == n {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidCode, s)
+}
+
+// Mapper Funcs
+// --------------------------------------------------------------------
+
+// NewMapper returns a Mapper with the default mapping.
+func NewMapper() *Mapper {
+	return &Mapper{mapping: bimap.New(toExitMap, fromExitMap)}
+}
+
+// ToExit returns the exit code of an HTTP status code. Codes without a
+// mapping map by class: 2xx to OK, 4xx to EX_DATAERR, 5xx to
+// EX_UNAVAILABLE and others to EX_PROTOCOL.
+func (m *Mapper) ToExit(status codes.StatusCode) Code {
+	if code, exists := m.mapping.To(status); exists {
+		return code
+	}
+
+	switch status.Class() {
+	case codes.Success:
+		return OK
+	case codes.ClientError:
+		return DataErr
+	case codes.ServerError:
+		return Unavailable
+	}
+	return Protocol
+}
+
+// FromExit returns the HTTP status code of an exit code. Codes without a
+// mapping map to 500 Internal Server Error.
+func (m *Mapper) FromExit(code Code) codes.StatusCode {
+	if status, exists := m.mapping.From(code); exists {
+		return status
+	}
+	return codes.InternalServerError
+}
+
+// SetToExit overrides the exit code an HTTP status code maps to.
+//
+// Example:
+//
+//	// Missing resources are usage errors of our tool
+//	m.SetToExit(codes.NotFound, sysexits.Usage)
+func (m *Mapper) SetToExit(status codes.StatusCode, code Code) error {
+	if err := validate(status, code); err != nil {
+		return err
+	}
+	m.mapping.SetTo(status, code)
+	return nil
+}
+
+// SetFromExit overrides the HTTP status code an exit code maps to.
+func (m *Mapper) SetFromExit(code Code, status codes.StatusCode) error {
+	if err := validate(status, code); err != nil {
+		return err
+	}
+	m.mapping.SetFrom(code, status)
+	return nil
+}
+
+// Override maps an HTTP status code and an exit code to each other.
+func (m *Mapper) Override(status codes.StatusCode, code Code) error {
+	if err := validate(status, code); err != nil {
+		return err
+	}
+	m.mapping.Override(status, code)
+	return nil
+}
+
+// Reset restores the default mapping, removing all overrides.
+func (m *Mapper) Reset() {
+	m.mapping.Reset()
+}
+
+// FromError returns the exit code and stderr message of err. The status code
+// of err is found with codes.FromError, so an HTTPError maps its own code.
+// A nil error returns OK and an empty message.
+//
+// Example:
+//
+//	code, msg := sysexits.FromError(codes.NewHTTPError(codes.Forbidden, "Token expired", nil))
+//	fmt.Println(code, msg) // Output: EX_NOPERM 403 Forbidden: Token expired
+func (m *Mapper) FromError(err error) (Code, string) {
+	if err == nil {
+		return OK, ""
+	}
+	return m.ToExit(codes.FromError(err)), err.Error()
+}
+
+// Package Funcs
+// --------------------------------------------------------------------
+
+// ToExit returns the exit code of an HTTP status code, using Default.
+func ToExit(status codes.StatusCode) Code {
+	return Default.ToExit(status)
+}
+
+// FromExit returns the HTTP status code of an exit code, using Default.
+func FromExit(code Code) codes.StatusCode {
+	return Default.FromExit(code)
+}
+
+// SetToExit overrides the mapping of an HTTP status code in Default.
+func SetToExit(status codes.StatusCode, code Code) error {
+	return Default.SetToExit(status, code)
+}
+
+// SetFromExit overrides the mapping of an exit code in Default.
+func SetFromExit(code Code, status codes.StatusCode) error {
+	return Default.SetFromExit(code, status)
+}
+
+// Override maps an HTTP status code and an exit code to each other in Default.
+func Override(status codes.StatusCode, code Code) error {
+	return Default.Override(status, code)
+}
+
+// Reset restores the default mapping of Default.
+func Reset() {
+	Default.Reset()
+}
+
+// FromError returns the exit code and stderr message of err, using Default.
+func FromError(err error) (Code, string) {
+	return Default.FromError(err)
+}
+
+// Fprint writes the message of err to w, prefixed with the program name as
+// "tool: message", and returns its exit code. Nothing is written for a nil error.
+func Fprint(w io.Writer, err error) Code {
+	code, msg := FromError(err)
+	if msg != "" {
+		fmt.Fprintf(w, "%s: %s\n", filepath.Base(os.Args[0]), msg)
+	}
+	return code
+}
+
+// Exit writes the message of err to stderr and exits with its exit code.
+//
+// Example:
+//
+//	if err := run(); err != nil {
+//	    sysexits.Exit(err)
+//	}
+func Exit(err error) {
+	os.Exit(int(Fprint(os.Stderr, err)))
+}
+
+// validate checks the codes of an override.
+func validate(status codes.StatusCode, code Code) error {
+	if !code.IsValid() {
+		return fmt.Errorf("%w: %d", ErrInvalidCode, int(code))
+	}
+	if !codes.IsValidStatusCode(status) {
+		return fmt.Errorf("%w: %d", ErrInvalidStatus, int(status))
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package codes
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Watch Types
+// --------------------------------------------------------------------
+
+// ChangeKind tells how a catalog entry changed between two reloads.
+type ChangeKind int
+
+// Change Kinds
+const (
+	ChangeAdded ChangeKind = iota + 1
+	ChangeRemoved
+	ChangeDescription
+)
+
+// String returns "added", "removed" or "description".
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeDescription:
+		return "description"
+	}
+	return "unknown"
+}
+
+// CatalogChange is a change of a catalog entry. Code is set for status codes,
+// Method for methods. Old is empty for added entries, New for removed ones.
+type CatalogChange struct {
+	Kind   ChangeKind
+	Code   StatusCode
+	Method Method
+	Old    Description
+	New    Description
+}
+
+// WatchOptions configures a CatalogWatcher.
+type WatchOptions struct {
+	// Interval between two checks of the file, defaults to 5 seconds.
+	Interval time.Duration
+	// OnChange is called for every change, including the entries added by the
+	// first load. It must not call Reload or Close.
+	OnChange func(CatalogChange)
+	// OnError is called when the file cannot be read or is invalid, once per
+	// version of the file. The entries of the last valid catalog stay
+	// registered. It may call Close.
+	OnError func(error)
+}
+
+// CatalogWatcher keeps the registry in sync with a catalog file.
+type CatalogWatcher struct {
+	path string
+	opts WatchOptions
+
+	// reloading serializes reloads and the delivery of their changes
+	reloading sync.Mutex
+	// modTime and size are the stat of the last attempt, -1 when missing
+	modTime time.Time
+	size    int64
+	// err is the error of the last attempt
+	err error
+
+	// lock guards the fields below
+	lock    sync.Mutex
+	catalog *Catalog
+	subs    []chan CatalogChange
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// ErrWatcherClosed is returned by Reload after Close.
+var ErrWatcherClosed = errors.New("catalog watcher closed")
+
+// Watch Funcs
+// --------------------------------------------------------------------
+
+// WatchCatalog loads the catalog at path and polls it for changes. Every
+// reload swaps the entries of the previous catalog for the new ones in a
+// single step, then reports the changes to subscribers.
+//
+// Example:
+//
+//	w, err := codes.WatchCatalog("payments.yaml", codes.WatchOptions{
+//	    Interval: 10 * time.Second,
+//	    OnError:  func(err error) { log.Println(err) },
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer w.Close()
+//
+//	for change := range w.Subscribe(16) {
+//	    log.Println(change.Kind, change.Code, change.New)
+//	}
+func WatchCatalog(path string, opts WatchOptions) (*CatalogWatcher, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+
+	w := &CatalogWatcher{
+		path: path,
+		opts: opts,
+		stop: make(chan struct{}),
+	}
+	if _, _, err := w.reload(true); err != nil {
+		return nil, err
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// Subscribe returns a channel receiving the changes of every reload. Changes
+// are dropped when the buffer of the channel is full, so a slow subscriber
+// never blocks the watcher. The channel is closed by Close.
+func (w *CatalogWatcher) Subscribe(buffer int) <-chan CatalogChange {
+	ch := make(chan CatalogChange, buffer)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	select {
+	case <-w.stop:
+		close(ch)
+	default:
+		w.subs = append(w.subs, ch)
+	}
+	return ch
+}
+
+// Catalog returns the catalog currently applied.
+func (w *CatalogWatcher) Catalog() *Catalog {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.catalog
+}
+
+// Reload checks the file now, without waiting for the next poll, and
+// returns the changes it applied. If the file did not change since a failed
+// attempt, the error of that attempt is returned again.
+func (w *CatalogWatcher) Reload() ([]CatalogChange, error) {
+	changes, _, err := w.reload(false)
+	return changes, err
+}
+
+// Close stops polling and closes the subscriber channels. Once it returns,
+// the registry is no longer updated. The entries of the current catalog stay
+// registered.
+func (w *CatalogWatcher) Close() error {
+	w.once.Do(func() {
+		close(w.stop)
+
+		// Waits for a reload in progress, later ones see stop closed
+		w.reloading.Lock()
+		defer w.reloading.Unlock()
+		w.lock.Lock()
+		for _, ch := range w.subs {
+			close(ch)
+		}
+		w.subs = nil
+		w.lock.Unlock()
+	})
+	return nil
+}
+
+// run polls the file until Close.
+func (w *CatalogWatcher) run() {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if _, checked, err := w.reload(false); checked && err != nil && w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+		}
+	}
+}
+
+// reload applies the file if it changed since the last attempt, or always if
+// force is set. checked is false when the file was left unchanged, err then
+// holds the error of the last attempt.
+func (w *CatalogWatcher) reload(force bool) (changes []CatalogChange, checked bool, err error) {
+	w.reloading.Lock()
+	defer w.reloading.Unlock()
+
+	select {
+	case <-w.stop:
+		return nil, false, ErrWatcherClosed
+	default:
+	}
+
+	modTime, size := time.Time{}, int64(-1)
+	info, err := os.Stat(w.path)
+	if err == nil {
+		modTime, size = info.ModTime(), info.Size()
+	}
+	if !force && modTime.Equal(w.modTime) && size == w.size {
+		return nil, false, w.err
+	}
+	w.modTime, w.size = modTime, size
+
+	defer func() { w.err = err }()
+	if err != nil {
+		return nil, true, err
+	}
+
+	next, err := ParseCatalogFile(w.path)
+	if err != nil {
+		return nil, true, err
+	}
+
+	w.lock.Lock()
+	prev := w.catalog
+	changes = diffCatalogs(prev, next)
+
+	mu.Lock()
+	if sealed.Load() {
+		mu.Unlock()
+		w.lock.Unlock()
+		return nil, true, sealedError()
+	}
+	notify := recordMutations(swapCatalogsLocked(prev, next)...)
+	mu.Unlock()
+
+	w.catalog = next
+	subs := append([]chan CatalogChange(nil), w.subs...)
+	w.lock.Unlock()
+
+	notify()
+
+	for _, change := range changes {
+		if w.opts.OnChange != nil {
+			w.opts.OnChange(change)
+		}
+		for _, ch := range subs {
+			select {
+			case ch <- change:
+			default:
+			}
+		}
+	}
+	return changes, true, nil
+}
+
+// diffCatalogs returns the changes from old to next, status codes first,
+// sorted by code or name.
+func diffCatalogs(old, next *Catalog) []CatalogChange {
+	if old == nil {
+		old = &Catalog{}
+	}
+
+	oldCodes := make(map[StatusCode]Description, len(old.StatusCodes))
+	for _, s := range old.StatusCodes {
+		oldCodes[s.Code] = s.Description
+	}
+	newCodes := make(map[StatusCode]Description, len(next.StatusCodes))
+	for _, s := range next.StatusCodes {
+		newCodes[s.Code] = s.Description
+	}
+
+	var codeChanges []CatalogChange
+	for code, desc := range newCodes {
+		prev, exists := oldCodes[code]
+		switch {
+		case !exists:
+			codeChanges = append(codeChanges, CatalogChange{Kind: ChangeAdded, Code: code, New: desc})
+		case prev != desc:
+			codeChanges = append(codeChanges, CatalogChange{Kind: ChangeDescription, Code: code, Old: prev, New: desc})
+		}
+	}
+	for code, desc := range oldCodes {
+		if _, exists := newCodes[code]; !exists {
+			codeChanges = append(codeChanges, CatalogChange{Kind: ChangeRemoved, Code: code, Old: desc})
+		}
+	}
+	sort.Slice(codeChanges, func(i, j int) bool { return codeChanges[i].Code < codeChanges[j].Code })
+
+	oldMethods := make(map[Method]Description, len(old.Methods))
+	for _, m := range old.Methods {
+		oldMethods[m.Name] = m.Description
+	}
+	newMethods := make(map[Method]Description, len(next.Methods))
+	for _, m := range next.Methods {
+		newMethods[m.Name] = m.Description
+	}
+
+	var methodChanges []CatalogChange
+	for method, desc := range newMethods {
+		prev, exists := oldMethods[method]
+		switch {
+		case !exists:
+			methodChanges = append(methodChanges, CatalogChange{Kind: ChangeAdded, Method: method, New: desc})
+		case prev != desc:
+			methodChanges = append(methodChanges, CatalogChange{Kind: ChangeDescription, Method: method, Old: prev, New: desc})
+		}
+	}
+	for method, desc := range oldMethods {
+		if _, exists := newMethods[method]; !exists {
+			methodChanges = append(methodChanges, CatalogChange{Kind: ChangeRemoved, Method: method, Old: desc})
+		}
+	}
+	sort.Slice(methodChanges, func(i, j int) bool { return methodChanges[i].Method < methodChanges[j].Method })
+
+	return append(codeChanges, methodChanges...)
+}
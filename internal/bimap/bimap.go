@@ -0,0 +1,92 @@
+// Package bimap holds the two-way mapping shared by the grpcmap and sysexits
+// packages: HTTP status codes to the codes of another protocol and back, with
+// overrides over a default mapping.
+package bimap
+
+import (
+	"sync"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+)
+
+// Map Types
+// --------------------------------------------------------------------
+
+// Map maps HTTP status codes and codes of type C in both directions.
+// It is safe for concurrent use.
+type Map[C comparable] struct {
+	mu          sync.RWMutex
+	to          map[codes.StatusCode]C
+	from        map[C]codes.StatusCode
+	defaultTo   map[codes.StatusCode]C
+	defaultFrom map[C]codes.StatusCode
+}
+
+// Map Funcs
+// --------------------------------------------------------------------
+
+// New returns a Map starting with the default mappings to and from, which
+// are kept unchanged.
+//
+// Example:
+//
+//	m := bimap.New(toGRPCMap, fromGRPCMap)
+func New[C comparable](to map[codes.StatusCode]C, from map[C]codes.StatusCode) *Map[C] {
+	m := &Map[C]{defaultTo: to, defaultFrom: from}
+	m.Reset()
+	return m
+}
+
+// To returns the code an HTTP status code maps to.
+func (m *Map[C]) To(status codes.StatusCode) (C, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	code, exists := m.to[status]
+	return code, exists
+}
+
+// From returns the HTTP status code a code maps to.
+func (m *Map[C]) From(code C) (codes.StatusCode, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	status, exists := m.from[code]
+	return status, exists
+}
+
+// SetTo overrides the code an HTTP status code maps to.
+func (m *Map[C]) SetTo(status codes.StatusCode, code C) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.to[status] = code
+}
+
+// SetFrom overrides the HTTP status code a code maps to.
+func (m *Map[C]) SetFrom(code C, status codes.StatusCode) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.from[code] = status
+}
+
+// Override maps an HTTP status code and a code to each other.
+func (m *Map[C]) Override(status codes.StatusCode, code C) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.to[status] = code
+	m.from[code] = status
+}
+
+// Reset restores the default mappings, removing all overrides.
+func (m *Map[C]) Reset() {
+	to := make(map[codes.StatusCode]C, len(m.defaultTo))
+	for k, v := range m.defaultTo {
+		to[k] = v
+	}
+	from := make(map[C]codes.StatusCode, len(m.defaultFrom))
+	for k, v := range m.defaultFrom {
+		from[k] = v
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.to, m.from = to, from
+}
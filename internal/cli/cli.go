@@ -0,0 +1,221 @@
+// Package cli implements the httpcode command line tool.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+)
+
+// Exit Codes
+const (
+	ExitOK       = 0
+	ExitNotFound = 1
+	ExitUsage    = 2
+	// ExitSoftware is EX_SOFTWARE of sysexits.h, for internal errors.
+	ExitSoftware = 70
+)
+
+const usage = `Usage:
+  httpcode [--json] <code|phrase>     Show a status code
+  httpcode [--json] search <query>    Search status codes and methods
+  httpcode [--json] class <class>     List the status codes of a class (1xx-5xx)
+  httpcode [--json] method <name>     Show a method
+`
+
+// Run executes the command line args and returns the process exit code.
+// The --json flag is accepted anywhere in args.
+func Run(args []string, stdout, stderr io.Writer) int {
+	var (
+		asJSON bool
+		rest   []string
+	)
+	for _, arg := range args {
+		switch arg {
+		case "--json", "-json", "-j":
+			asJSON = true
+		case "-h", "--help", "help":
+			fmt.Fprint(stdout, usage)
+			return ExitOK
+		default:
+			rest = append(rest, arg)
+		}
+	}
+
+	if len(rest) == 0 {
+		fmt.Fprint(stderr, usage)
+		return ExitUsage
+	}
+
+	out := &output{w: stdout, json: asJSON}
+
+	switch rest[0] {
+	case "search":
+		if len(rest) < 2 {
+			fmt.Fprint(stderr, usage)
+			return ExitUsage
+		}
+		return out.search(strings.Join(rest[1:], " "), stderr)
+	case "class":
+		if len(rest) != 2 {
+			fmt.Fprint(stderr, usage)
+			return ExitUsage
+		}
+		return out.class(rest[1], stderr)
+	case "method":
+		if len(rest) != 2 {
+			fmt.Fprint(stderr, usage)
+			return ExitUsage
+		}
+		return out.method(rest[1], stderr)
+	default:
+		return out.status(strings.Join(rest, " "), stderr)
+	}
+}
+
+// Commands
+// --------------------------------------------------------------------
+
+// output writes command results as text or JSON.
+type output struct {
+	w    io.Writer
+	json bool
+}
+
+// status shows a single status code from its number or phrase.
+func (o *output) status(query string, stderr io.Writer) int {
+	code, err := codes.ParseStatusCode(query)
+	if err != nil {
+		fmt.Fprintf(stderr, "httpcode: %v\n", err)
+		return ExitNotFound
+	}
+
+	info, _ := codes.LookupStatus(code)
+	if o.json {
+		return o.writeJSON(info, stderr)
+	}
+
+	fmt.Fprintf(o.w, "%d %s\n", int(info.Code), info.Phrase)
+	fmt.Fprintf(o.w, "  Description: %s\n", info.Description)
+	fmt.Fprintf(o.w, "  Class:       %s (%s)\n", info.Class, info.Class.Name())
+	if info.Reference != "" {
+		fmt.Fprintf(o.w, "  Reference:   %s\n", info.Reference)
+	}
+	fmt.Fprintf(o.w, "  Cacheable:   %s\n", yesNo(info.Cacheable))
+	fmt.Fprintf(o.w, "  Retryable:   %s\n", yesNo(info.Retryable))
+	return ExitOK
+}
+
+// search lists the status codes and methods matching the query, best first.
+func (o *output) search(query string, stderr io.Writer) int {
+	matches := codes.Search(query)
+
+	if o.json {
+		if matches == nil {
+			matches = []codes.Match{}
+		}
+		if exit := o.writeJSON(matches, stderr); exit != ExitOK {
+			return exit
+		}
+		if len(matches) == 0 {
+			return ExitNotFound
+		}
+		return ExitOK
+	}
+
+	if len(matches) == 0 {
+		fmt.Fprintf(stderr, "httpcode: nothing matches %q\n", query)
+		return ExitNotFound
+	}
+
+	for _, m := range matches {
+		id := string(m.Method)
+		if m.Kind == codes.MatchStatusCode {
+			id = strconv.Itoa(int(m.Code))
+		}
+		fmt.Fprintf(o.w, "%-7s %-32s %3.0f  %s\n", id, m.Name, m.Score, m.Description)
+	}
+	return ExitOK
+}
+
+// class lists every status code of a class.
+func (o *output) class(query string, stderr io.Writer) int {
+	class, err := codes.ParseClass(query)
+	if err != nil {
+		fmt.Fprintf(stderr, "httpcode: %v\n", err)
+		return ExitNotFound
+	}
+
+	var found []codes.StatusInfo
+	for _, code := range class.StatusCodes() {
+		if info, ok := codes.LookupStatus(code); ok {
+			found = append(found, info)
+		}
+	}
+	return o.list(found, stderr)
+}
+
+// method shows a single method.
+func (o *output) method(query string, stderr io.Writer) int {
+	info, ok := codes.LookupMethod(codes.Method(strings.ToUpper(query)))
+	if !ok {
+		info, ok = codes.LookupMethod(codes.Method(query))
+	}
+	if !ok {
+		fmt.Fprintf(stderr, "httpcode: %v: %q\n", codes.ErrUnknownMethod, query)
+		return ExitNotFound
+	}
+
+	if o.json {
+		return o.writeJSON(info, stderr)
+	}
+
+	fmt.Fprintf(o.w, "%s\n", info.Method)
+	fmt.Fprintf(o.w, "  Description: %s\n", info.Description)
+	fmt.Fprintf(o.w, "  Safe:        %s\n", yesNo(info.Safe))
+	fmt.Fprintf(o.w, "  Idempotent:  %s\n", yesNo(info.Idempotent))
+	if info.Reference != "" {
+		fmt.Fprintf(o.w, "  Reference:   %s\n", info.Reference)
+	}
+	return ExitOK
+}
+
+// Utils
+// --------------------------------------------------------------------
+
+// list writes one line per status code, or a JSON array.
+func (o *output) list(infos []codes.StatusInfo, stderr io.Writer) int {
+	if o.json {
+		if infos == nil {
+			infos = []codes.StatusInfo{}
+		}
+		return o.writeJSON(infos, stderr)
+	}
+
+	for _, info := range infos {
+		fmt.Fprintf(o.w, "%d  %-32s %s\n", int(info.Code), info.Phrase, info.Description)
+	}
+	return ExitOK
+}
+
+// writeJSON writes v as indented JSON.
+func (o *output) writeJSON(v interface{}, stderr io.Writer) int {
+	enc := json.NewEncoder(o.w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintf(stderr, "httpcode: %v\n", err)
+		return ExitSoftware
+	}
+	return ExitOK
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,20 @@
+// Package delay holds the cancelable sleep shared by the chaos and codestest
+// packages to delay responses.
+package delay
+
+import (
+	"context"
+	"time"
+)
+
+// Sleep waits for d and returns false if ctx is done first.
+func Sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,492 @@
+// Package gen generates the status code and method tables of the codes
+// package from the vendored IANA registries.
+//
+// The data directory holds two kinds of CSV files:
+//
+//   - iana/http-status-codes.csv and iana/methods.csv are copies of the IANA
+//     HTTP Status Code and HTTP Method registries. They provide reason
+//     phrases, references and method semantics.
+//   - overlay/status-codes.csv and overlay/methods.csv select the registry
+//     entries exported by the package and give them a Go name and a
+//     human-readable description. Optional Phrase and Reference columns
+//     override the IANA reason phrase and reference. Status codes outside
+//     the registry, such as 499, must set both.
+//
+// Updating for a new RFC is a data change: refresh the IANA files, add a
+// row to the overlay and run go generate.
+package gen
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Types
+// --------------------------------------------------------------------
+
+// Status is a generated status code entry.
+type Status struct {
+	Code        int
+	Name        string
+	Phrase      string
+	Description string
+	Reference   string
+}
+
+// Method is a generated method entry. Const is empty for registry methods
+// the package does not export.
+type Method struct {
+	Name        string
+	Const       string
+	Description string
+	Safe        bool
+	Idempotent  bool
+	Reference   string
+}
+
+// Class groups the generated status codes of a class.
+type Class struct {
+	Title    string
+	MapTitle string
+	Statuses []Status
+}
+
+// Registry is the data handed to the template.
+type Registry struct {
+	Classes []Class
+	// Methods exported by the package, in overlay order.
+	Methods []Method
+	// AllMethods holds every method of the IANA registry, for semantics.
+	AllMethods []Method
+}
+
+// Data Files
+const (
+	ianaStatusFile    = "iana/http-status-codes.csv"
+	ianaMethodFile    = "iana/methods.csv"
+	overlayStatusFile = "overlay/status-codes.csv"
+	overlayMethodFile = "overlay/methods.csv"
+)
+
+var classTitles = []struct {
+	title    string
+	mapTitle string
+}{
+	{"Informational 1xx", "1xx Informational"},
+	{"Success 2xx", "2xx Success"},
+	{"Redirection 3xx", "3xx Redirection"},
+	{"Client Error 4xx", "4xx Client Errors"},
+	{"Server Error 5xx", "5xx Server Errors"},
+}
+
+var goName = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// Generate Funcs
+// --------------------------------------------------------------------
+
+// Generate reads the CSV files under dataDir and returns the formatted Go source.
+func Generate(dataDir string) ([]byte, error) {
+	reg, err := Load(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, reg); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("gen: formatting output: %w", err)
+	}
+	return src, nil
+}
+
+// Check generates the source and compares it with the file at path.
+// It returns an error if the file drifted from the generated output.
+func Check(dataDir, path string) error {
+	want, err := Generate(dataDir)
+	if err != nil {
+		return err
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("gen: %s differs from the generated output, run go generate ./codes", path)
+	}
+	return nil
+}
+
+// Load reads and validates the CSV files under dataDir.
+func Load(dataDir string) (*Registry, error) {
+	ianaStatuses, _, err := readCSV(filepath.Join(dataDir, ianaStatusFile), 3)
+	if err != nil {
+		return nil, err
+	}
+	ianaMethods, ianaMethodLines, err := readCSV(filepath.Join(dataDir, ianaMethodFile), 4)
+	if err != nil {
+		return nil, err
+	}
+	overlayStatuses, overlayStatusLines, err := readCSV(filepath.Join(dataDir, overlayStatusFile), 5)
+	if err != nil {
+		return nil, err
+	}
+	overlayMethods, overlayMethodLines, err := readCSV(filepath.Join(dataDir, overlayMethodFile), 2)
+	if err != nil {
+		return nil, err
+	}
+
+	reg := &Registry{Classes: make([]Class, len(classTitles))}
+	for i, t := range classTitles {
+		reg.Classes[i] = Class{Title: t.title, MapTitle: t.mapTitle}
+	}
+
+	// Status Codes
+
+	registered := map[int][]string{}
+	for _, row := range ianaStatuses {
+		code, err := strconv.Atoi(row[0])
+		if err != nil {
+			// Unassigned ranges such as 104-199
+			continue
+		}
+		registered[code] = row
+	}
+
+	names := map[string]bool{}
+	seen := map[int]bool{}
+	for i, row := range overlayStatuses {
+		line := overlayStatusLines[i]
+		code, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, fmt.Errorf("gen: %s:%d: invalid status code %q", overlayStatusFile, line, row[0])
+		}
+		iana, exists := registered[code]
+		if !exists {
+			if row[2] == "" || row[4] == "" {
+				return nil, fmt.Errorf("gen: %s:%d: status code %d is not in the IANA registry, set a Phrase and a Reference", overlayStatusFile, line, code)
+			}
+			iana = []string{row[0], row[2], ""}
+		}
+		if seen[code] {
+			return nil, fmt.Errorf("gen: %s:%d: duplicate status code %d", overlayStatusFile, line, code)
+		}
+		if err := checkName(row[1], names); err != nil {
+			return nil, fmt.Errorf("gen: %s:%d: %w", overlayStatusFile, line, err)
+		}
+		if row[3] == "" {
+			return nil, fmt.Errorf("gen: %s:%d: empty description for %d", overlayStatusFile, line, code)
+		}
+		if code < 100 || code > 599 {
+			return nil, fmt.Errorf("gen: %s:%d: status code %d out of range", overlayStatusFile, line, code)
+		}
+
+		phrase := row[2]
+		if phrase == "" {
+			phrase = iana[1]
+		}
+		if strings.HasPrefix(phrase, "(") {
+			return nil, fmt.Errorf("gen: %s:%d: status code %d is %s in the IANA registry, set a Phrase", overlayStatusFile, line, code, phrase)
+		}
+
+		reference := row[4]
+		if reference == "" {
+			reference = formatReference(iana[2])
+		}
+
+		seen[code] = true
+		class := &reg.Classes[code/100-1]
+		class.Statuses = append(class.Statuses, Status{
+			Code:        code,
+			Name:        row[1],
+			Phrase:      phrase,
+			Description: row[3],
+			Reference:   reference,
+		})
+	}
+
+	for i := range reg.Classes {
+		statuses := reg.Classes[i].Statuses
+		for j := 1; j < len(statuses); j++ {
+			if statuses[j].Code < statuses[j-1].Code {
+				return nil, fmt.Errorf("gen: %s: status code %d listed after %d", overlayStatusFile, statuses[j].Code, statuses[j-1].Code)
+			}
+		}
+	}
+
+	// Methods
+
+	methods := map[string]Method{}
+	for i, row := range ianaMethods {
+		safe, err := parseYesNo(row[1])
+		if err != nil {
+			return nil, fmt.Errorf("gen: %s:%d: %w", ianaMethodFile, ianaMethodLines[i], err)
+		}
+		idempotent, err := parseYesNo(row[2])
+		if err != nil {
+			return nil, fmt.Errorf("gen: %s:%d: %w", ianaMethodFile, ianaMethodLines[i], err)
+		}
+		m := Method{Name: row[0], Safe: safe, Idempotent: idempotent, Reference: formatReference(row[3])}
+		methods[m.Name] = m
+	}
+
+	for i, row := range overlayMethods {
+		line := overlayMethodLines[i]
+		m, exists := methods[row[0]]
+		if !exists {
+			return nil, fmt.Errorf("gen: %s:%d: method %q is not in the IANA registry", overlayMethodFile, line, row[0])
+		}
+		if err := checkName(row[0], names); err != nil {
+			return nil, fmt.Errorf("gen: %s:%d: %w", overlayMethodFile, line, err)
+		}
+		if row[1] == "" {
+			return nil, fmt.Errorf("gen: %s:%d: empty description for %s", overlayMethodFile, line, row[0])
+		}
+		m.Const = row[0]
+		m.Description = row[1]
+		methods[m.Name] = m
+		reg.Methods = append(reg.Methods, m)
+	}
+
+	for _, row := range ianaMethods {
+		reg.AllMethods = append(reg.AllMethods, methods[row[0]])
+	}
+	return reg, nil
+}
+
+// Utils
+// --------------------------------------------------------------------
+
+// readCSV reads a CSV file, skipping its header row, and checks every
+// row has the given number of columns. It also returns the line each row
+// starts on, as quoted fields may span several lines.
+func readCSV(path string, columns int) ([][]string, []int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("gen: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = columns
+
+	var rows [][]string
+	var lines []int
+	for first := true; ; first = false {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("gen: %s: %w", path, err)
+		}
+		if first {
+			continue
+		}
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+		line, _ := r.FieldPos(0)
+		rows = append(rows, row)
+		lines = append(lines, line)
+	}
+	return rows, lines, nil
+}
+
+// checkName validates a Go identifier and records it in names.
+func checkName(name string, names map[string]bool) error {
+	if !goName.MatchString(name) {
+		return fmt.Errorf("invalid Go name %q", name)
+	}
+	if names[name] || names[name+"Desc"] {
+		return fmt.Errorf("duplicate Go name %q", name)
+	}
+	names[name] = true
+	names[name+"Desc"] = true
+	return nil
+}
+
+func parseYesNo(s string) (bool, error) {
+	switch s {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("expected yes or no, got %q", s)
+}
+
+var (
+	referenceGroup = regexp.MustCompile(`\[([^\]]*)\]`)
+	referenceRFC   = regexp.MustCompile(`^RFC(\d+)`)
+)
+
+// formatReference turns IANA references such as "[RFC9110, Section 15.2.1]"
+// into "RFC 9110, Section 15.2.1". Several references are joined with "; ".
+func formatReference(s string) string {
+	var refs []string
+	for _, m := range referenceGroup.FindAllStringSubmatch(s, -1) {
+		refs = append(refs, referenceRFC.ReplaceAllString(strings.TrimSpace(m[1]), "RFC $1"))
+	}
+	return strings.Join(refs, "; ")
+}
+
+// Template
+// --------------------------------------------------------------------
+
+var tmpl = template.Must(template.New("codes").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(`// Code generated by internal/gen/gencodes from the IANA registries; DO NOT EDIT.
+
+package codes
+
+// Status Codes Constants
+// --------------------------------------------------------------------
+
+// HTTP Status Codes
+const (
+{{- range .Classes}}
+
+	// {{.Title}}
+{{range .Statuses}}
+	{{.Name}} StatusCode = {{.Code}}
+{{- end}}
+{{- end}}
+)
+
+// StatusCode Descriptions
+// --------------------------------------------------------------------
+
+const (
+{{- range .Classes}}
+
+	// {{.Title}}
+{{range .Statuses}}
+	{{.Name}}Desc Description = {{quote .Description}}
+{{- end}}
+{{- end}}
+)
+
+// StatusDescriptionMap maps status codes to their descriptions.
+//
+// Example:
+//
+//	desc := StatusDescriptionMap[OK]
+//	fmt.Println(desc) // Output: "Request succeeded and response contains requested data"
+var StatusDescriptionMap = map[StatusCode]Description{
+{{- range $i, $c := .Classes}}
+{{- if $i}}
+{{end}}
+	// {{$c.MapTitle}}
+{{- range $c.Statuses}}
+	{{.Name}}: {{.Name}}Desc,
+{{- end}}
+{{- end}}
+}
+
+// StatusPhraseMap maps status codes to their standard reason phrases,
+// as listed in the IANA HTTP Status Code Registry.
+//
+// Example:
+//
+//	phrase := StatusPhraseMap[NotFound]
+//	fmt.Println(phrase) // Output: "Not Found"
+var StatusPhraseMap = map[StatusCode]string{
+{{- range $i, $c := .Classes}}
+{{- if $i}}
+{{end}}
+	// {{$c.MapTitle}}
+{{- range $c.Statuses}}
+	{{.Name}}: {{quote .Phrase}},
+{{- end}}
+{{- end}}
+}
+
+// StatusReferenceMap maps status codes to the specification defining them.
+var StatusReferenceMap = map[StatusCode]string{
+{{- range $i, $c := .Classes}}
+{{- if $i}}
+{{end}}
+	// {{$c.MapTitle}}
+{{- range $c.Statuses}}
+	{{.Name}}: {{quote .Reference}},
+{{- end}}
+{{- end}}
+}
+
+// Method Constants
+// --------------------------------------------------------------------
+
+// Method is a type for HTTP request methods.
+const (
+{{- range .Methods}}
+	{{.Const}} Method = {{quote .Name}}
+{{- end}}
+)
+
+// Method Descriptions
+const (
+{{- range .Methods}}
+	{{.Const}}Desc Description = {{quote .Description}}
+{{- end}}
+)
+
+// MethodDescriptionMap maps HTTP methods to their descriptions.
+//
+// Map:
+{{- range .Methods}}
+//   - {{.Name}}: {{quote .Description}}
+{{- end}}
+//
+// Example:
+//
+//	desc := MethodDescriptionMap[GET]
+//	fmt.Println(desc) // Output: "Retrieve data from server"
+var MethodDescriptionMap = map[Method]Description{
+{{- range .Methods}}
+	{{.Const}}: {{.Const}}Desc,
+{{- end}}
+}
+
+// MethodReferenceMap maps methods to the specification defining them.
+var MethodReferenceMap = map[Method]string{
+{{- range .Methods}}
+	{{.Const}}: {{quote .Reference}},
+{{- end}}
+}
+
+// ianaMethods holds every method of the IANA registry.
+var ianaMethods = map[Method]bool{
+{{- range .AllMethods}}
+	{{if .Const}}{{.Const}}{{else}}{{quote .Name}}{{end}}: true,
+{{- end}}
+}
+
+// safeMethods holds every method of the IANA registry defined as safe.
+var safeMethods = map[Method]bool{
+{{- range .AllMethods}}{{if .Safe}}
+	{{if .Const}}{{.Const}}{{else}}{{quote .Name}}{{end}}: true,
+{{- end}}{{end}}
+}
+
+// idempotentMethods holds every method of the IANA registry defined as idempotent.
+var idempotentMethods = map[Method]bool{
+{{- range .AllMethods}}{{if .Idempotent}}
+	{{if .Const}}{{.Const}}{{else}}{{quote .Name}}{{end}}: true,
+{{- end}}{{end}}
+}
+`))
@@ -0,0 +1,40 @@
+// Command gencodes generates codes/codes_gen.go from the vendored IANA registries.
+//
+// Usage, from the codes directory:
+//
+//	go run ../internal/gen/gencodes            Write the generated file
+//	go run ../internal/gen/gencodes -check     Fail if the file drifted from the data
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/JuniorVieira99/jr_httpcodes/internal/gen"
+)
+
+func main() {
+	data := flag.String("data", "../internal/gen/data", "directory holding the iana and overlay CSV files")
+	out := flag.String("out", "codes_gen.go", "generated Go file")
+	check := flag.Bool("check", false, "compare the generated output with -out instead of writing it")
+	flag.Parse()
+
+	if *check {
+		if err := gen.Check(*data, *out); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	src, err := gen.Generate(*data)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
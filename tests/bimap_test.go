@@ -0,0 +1,41 @@
+package code_test
+
+import (
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/internal/bimap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBimap(t *testing.T) {
+	to := map[codes.StatusCode]string{codes.NotFound: "missing"}
+	from := map[string]codes.StatusCode{"missing": codes.NotFound}
+	m := bimap.New(to, from)
+
+	code, ok := m.To(codes.NotFound)
+	assert.True(t, ok)
+	assert.Equal(t, "missing", code)
+	_, ok = m.To(codes.Conflict)
+	assert.False(t, ok)
+
+	m.Override(codes.Gone, "missing")
+	m.SetTo(codes.Conflict, "taken")
+	m.SetFrom("taken", codes.UnprocessableEntity)
+	code, _ = m.To(codes.Gone)
+	assert.Equal(t, "missing", code)
+	status, _ := m.From("missing")
+	assert.Equal(t, codes.Gone, status)
+	status, _ = m.From("taken")
+	assert.Equal(t, codes.UnprocessableEntity, status)
+
+	// Overrides never touch the defaults
+	assert.Len(t, to, 1)
+	assert.Len(t, from, 1)
+
+	m.Reset()
+	status, _ = m.From("missing")
+	assert.Equal(t, codes.NotFound, status)
+	_, ok = m.To(codes.Conflict)
+	assert.False(t, ok)
+}
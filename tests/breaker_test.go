@@ -0,0 +1,246 @@
+package code_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/codes/breaker"
+	"github.com/JuniorVieira99/jr_httpcodes/codes/codestest"
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTripFunc is an http.RoundTripper function.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// stubTransport replies with the status code or error set by the test.
+type stubTransport struct {
+	mu    sync.Mutex
+	code  int
+	err   error
+	calls int
+}
+
+func (s *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &http.Response{StatusCode: s.code, Body: http.NoBody, Request: req}, nil
+}
+
+func (s *stubTransport) set(code int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.code, s.err = code, err
+}
+
+// send sends n requests through b and returns the last error.
+func send(b *breaker.Breaker, n int) error {
+	var err error
+	for i := 0; i < n; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "http://orders.test/", nil)
+		var resp *http.Response
+		if resp, err = b.RoundTrip(req); err == nil {
+			resp.Body.Close()
+		}
+	}
+	return err
+}
+
+func TestBreakerStates(t *testing.T) {
+	clock := breaker.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	transport := &stubTransport{code: 200}
+	var changes []string
+	b := breaker.New(transport, breaker.Options{
+		MinRequests:      4,
+		FailureRatio:     0.5,
+		OpenTimeout:      time.Minute,
+		HalfOpenRequests: 2,
+		Clock:            clock,
+		OnStateChange: func(from, to breaker.State) {
+			changes = append(changes, from.String()+" -> "+to.String())
+		},
+	})
+
+	// Failures below the minimum number of requests keep the breaker closed
+	transport.set(503, nil)
+	assert.NoError(t, send(b, 3))
+	assert.Equal(t, breaker.Closed, b.State())
+	assert.Equal(t, breaker.Counts{Requests: 3, Failures: 3}, b.Counts())
+
+	// The fourth failure opens it
+	assert.NoError(t, send(b, 1))
+	assert.Equal(t, breaker.Open, b.State())
+
+	err := send(b, 1)
+	assert.ErrorIs(t, err, breaker.ErrOpen)
+	assert.Equal(t, "circuit breaker is open: retry in 1m0s", err.Error())
+	assert.Equal(t, codes.ServiceUnavailable, codes.FromError(err))
+	assert.Equal(t, 4, transport.calls)
+
+	// After the timeout, probes are let through
+	clock.Advance(time.Minute)
+	assert.Equal(t, breaker.HalfOpen, b.State())
+	transport.set(200, nil)
+	assert.NoError(t, send(b, 1))
+	assert.Equal(t, breaker.HalfOpen, b.State())
+	assert.NoError(t, send(b, 1))
+	assert.Equal(t, breaker.Closed, b.State())
+	assert.Equal(t, breaker.Counts{}, b.Counts())
+
+	// A failed probe opens it again
+	transport.set(500, nil)
+	assert.NoError(t, send(b, 4))
+	clock.Advance(time.Minute)
+	assert.NoError(t, send(b, 1))
+	assert.Equal(t, breaker.Open, b.State())
+
+	assert.Equal(t, []string{
+		"closed -> open",
+		"open -> half-open",
+		"half-open -> closed",
+		"closed -> open",
+		"open -> half-open",
+		"half-open -> open",
+	}, changes)
+
+	b.Reset()
+	assert.Equal(t, breaker.Closed, b.State())
+}
+
+func TestBreakerClassification(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    breaker.Options
+		code    int
+		err     error
+		failure bool
+	}{
+		{"server error", breaker.Options{}, 500, nil, true},
+		{"too many requests", breaker.Options{}, 429, nil, true},
+		{"client error", breaker.Options{}, 404, nil, false},
+		{"success", breaker.Options{}, 200, nil, false},
+		{"transport error", breaker.Options{}, 0, errors.New("connection refused"), true},
+		{"timeout", breaker.Options{}, 0, context.DeadlineExceeded, true},
+		{"canceled", breaker.Options{}, 0, context.Canceled, false},
+		{"custom codes", breaker.Options{FailureCodes: codes.RetryableStatusCodes()}, 501, nil, false},
+		{"custom codes retryable", breaker.Options{FailureCodes: codes.RetryableStatusCodes()}, 408, nil, true},
+		{"custom classes", breaker.Options{FailureClasses: []codes.Class{codes.ClientError}}, 404, nil, true},
+		{"custom classes ignore 5xx", breaker.Options{FailureClasses: []codes.Class{codes.ClientError}}, 500, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Clock = breaker.NewFakeClock(time.Now())
+			b := breaker.New(&stubTransport{code: tt.code, err: tt.err}, tt.opts)
+			send(b, 1)
+
+			want := breaker.Counts{Requests: 1}
+			if tt.failure {
+				want.Failures = 1
+			}
+			if tt.err == context.Canceled {
+				want.Requests = 0
+			}
+			assert.Equal(t, want, b.Counts())
+		})
+	}
+}
+
+func TestBreakerRollingWindow(t *testing.T) {
+	clock := breaker.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	transport := &stubTransport{code: 500}
+	b := breaker.New(transport, breaker.Options{Window: 10 * time.Second, Buckets: 10, MinRequests: 10, Clock: clock})
+
+	// Failures spread over more than the window never reach the minimum
+	for i := 0; i < 30; i++ {
+		assert.NoError(t, send(b, 1))
+		clock.Advance(2 * time.Second)
+	}
+	assert.Equal(t, breaker.Closed, b.State())
+	assert.Equal(t, 4, b.Counts().Requests)
+
+	// Old buckets expire
+	clock.Advance(10 * time.Second)
+	assert.Equal(t, breaker.Counts{}, b.Counts())
+
+	// Successes keep the ratio below the threshold
+	transport.set(200, nil)
+	assert.NoError(t, send(b, 6))
+	transport.set(500, nil)
+	assert.NoError(t, send(b, 5))
+	assert.Equal(t, breaker.Closed, b.State())
+	assert.NoError(t, send(b, 1))
+	assert.Equal(t, breaker.Open, b.State())
+}
+
+func TestBreakerHalfOpenLimit(t *testing.T) {
+	clock := breaker.NewFakeClock(time.Now())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	b := breaker.New(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/slow" {
+			return &http.Response{StatusCode: 503, Body: http.NoBody}, nil
+		}
+		close(started)
+		<-release
+		return &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+	}), breaker.Options{MinRequests: 1, Clock: clock})
+
+	assert.NoError(t, send(b, 1))
+	clock.Advance(30 * time.Second)
+
+	// A probe in flight rejects other requests
+	done := make(chan error)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, "http://orders.test/slow", nil)
+		_, err := b.RoundTrip(req)
+		done <- err
+	}()
+	<-started
+
+	var openErr *breaker.OpenError
+	assert.True(t, errors.As(send(b, 1), &openErr))
+	assert.Equal(t, breaker.HalfOpen, openErr.State)
+
+	close(release)
+	assert.NoError(t, <-done)
+	assert.Equal(t, breaker.Closed, b.State())
+}
+
+func TestBreakerWithScriptedServer(t *testing.T) {
+	srv := codestest.NewScriptedServer(map[string]codestest.Script{
+		"/orders": {Codes: []codes.StatusCode{503, 503, 503, 200}},
+	})
+	defer srv.Close()
+
+	clock := breaker.NewFakeClock(time.Now())
+	client := &http.Client{Transport: breaker.New(nil, breaker.Options{MinRequests: 3, Clock: clock})}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(srv.URL + "/orders")
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	_, err := client.Get(srv.URL + "/orders")
+	assert.ErrorIs(t, err, breaker.ErrOpen)
+	assert.Equal(t, 3, srv.Count("/orders"))
+
+	clock.Advance(30 * time.Second)
+	resp, err := client.Get(srv.URL + "/orders")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	codestest.AssertStatus(t, resp, codes.OK)
+}
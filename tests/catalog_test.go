@@ -0,0 +1,194 @@
+package code_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+const yamlCatalog = `name: payments
+status_codes:
+  - code: 701
+    phrase: Payment Declined
+    description: Payment provider declined the transaction
+    phrases:
+      pt-BR: Pagamento Recusado
+    metadata:
+      owner: payments-team
+  - code: 702
+    description: Card expired
+methods:
+  - name: PURGE
+    description: Remove a cached resource
+    idempotent: true
+`
+
+const jsonCatalog = `{
+  "name": "payments",
+  "status_codes": [
+    {"code": 701, "phrase": "Payment Declined", "description": "Payment provider declined the transaction"}
+  ],
+  "methods": [
+    {"name": "PURGE", "description": "Remove a cached resource", "safe": true}
+  ]
+}`
+
+func TestLoadCatalogYAML(t *testing.T) {
+	c, err := codes.LoadCatalog(strings.NewReader(yamlCatalog))
+	assert.NoError(t, err)
+	defer c.Remove()
+
+	assert.Equal(t, "payments", c.Name)
+	assert.Len(t, c.StatusCodes, 2)
+	assert.Len(t, c.Methods, 1)
+
+	// Status codes
+	_, ok := codes.LookupStatus(701)
+	assert.True(t, ok)
+	assert.Equal(t, "Payment Declined", codes.GetStatusPhrase(701))
+	assert.Equal(t, "Payment provider declined the transaction", codes.GetStatusInfo(701))
+	assert.Equal(t, "Card expired", codes.GetStatusPhrase(702))
+	assert.Equal(t, map[string]string{"owner": "payments-team"}, codes.GetStatusMetadata(701))
+	assert.Nil(t, codes.GetStatusMetadata(702))
+
+	phrase, ok := codes.GetLocalizedStatusPhrase(701, "pt-br")
+	assert.True(t, ok)
+	assert.Equal(t, "Pagamento Recusado", phrase)
+	_, ok = codes.GetLocalizedStatusPhrase(701, "fr")
+	assert.False(t, ok)
+
+	// Methods
+	assert.Equal(t, "Remove a cached resource", codes.GetMethodDescription("PURGE"))
+	assert.False(t, codes.IsSafeMethod("PURGE"))
+	assert.True(t, codes.IsIdempotentMethod("PURGE"))
+
+	// Remove
+	c.Remove()
+	_, ok = codes.LookupStatus(701)
+	assert.False(t, ok)
+	assert.Equal(t, "Unknown Method", codes.GetMethodDescription("PURGE"))
+	assert.False(t, codes.IsIdempotentMethod("PURGE"))
+}
+
+func TestLoadCatalogJSON(t *testing.T) {
+	c, err := codes.LoadCatalog(strings.NewReader(jsonCatalog))
+	assert.NoError(t, err)
+	defer c.Remove()
+
+	assert.Equal(t, "Payment Declined", codes.GetStatusPhrase(701))
+	assert.True(t, codes.IsSafeMethod("PURGE"))
+	// Safe methods are idempotent
+	assert.True(t, codes.IsIdempotentMethod("PURGE"))
+}
+
+func TestCatalogBuiltInCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog codes.Catalog
+		errMsg  string
+	}{
+		{"Built-in Code", codes.Catalog{StatusCodes: []codes.CatalogStatus{{Code: 404, Phrase: "Hijacked", Description: "hijacked"}}}, "status code 404 is in the built-in range (100-600)"},
+		{"Two Digit Code", codes.Catalog{StatusCodes: []codes.CatalogStatus{{Code: 42, Description: "Answer"}}}, "status code 42 must have three digits"},
+		{"Empty Description", codes.Catalog{StatusCodes: []codes.CatalogStatus{{Code: 701, Description: " "}}}, "status code 701 has no description"},
+		{"Standard Method", codes.Catalog{Methods: []codes.CatalogMethod{{Name: "GET", Description: "x"}}}, "method GET is built-in"},
+		{"IANA Method", codes.Catalog{Methods: []codes.CatalogMethod{{Name: "PROPFIND", Description: "Retrieve properties"}}}, "method PROPFIND is in the IANA registry"},
+		{"Invalid Method", codes.Catalog{Methods: []codes.CatalogMethod{{Name: "MY METHOD", Description: "x"}}}, "method \"MY METHOD\" is not a valid token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.catalog.Apply()
+			assert.ErrorIs(t, err, codes.ErrInvalidCatalog)
+			assert.Equal(t, "catalog: invalid catalog: "+tt.errMsg, err.Error())
+			assert.ErrorIs(t, tt.catalog.Remove(), codes.ErrInvalidCatalog)
+
+			// Built-in entries are untouched
+			assert.Equal(t, "Not Found", codes.GetStatusPhrase(codes.NotFound))
+			assert.Equal(t, string(codes.NotFoundDesc), codes.GetStatusInfo(codes.NotFound))
+			assert.NoError(t, codes.ValidateMethod("GET"))
+			assert.True(t, codes.IsSafeMethod("PROPFIND"))
+		})
+	}
+}
+
+func TestLoadCatalogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "payments.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(yamlCatalog), 0o644))
+
+	c, err := codes.LoadCatalogFile(path)
+	assert.NoError(t, err)
+	defer c.Remove()
+	_, ok := codes.LookupStatus(702)
+	assert.True(t, ok)
+
+	// Errors carry the path
+	bad := filepath.Join(t.TempDir(), "bad.yaml")
+	assert.NoError(t, os.WriteFile(bad, []byte("status_codes:\n  - code: 404\n    description: x\n"), 0o644))
+	_, err = codes.LoadCatalogFile(bad)
+	assert.EqualError(t, err, bad+":2: invalid catalog: status code 404 is in the built-in range (100-600)")
+
+	_, err = codes.LoadCatalogFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestParseCatalogErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		line int
+		msg  string
+	}{
+		{"not a mapping", "- 1\n", 1, "expected a mapping at the top level"},
+		{"unknown top field", "name: x\nversion: 2\n", 2, `unknown field "version"`},
+		{"unknown entry field", "status_codes:\n  - code: 701\n    description: x\n    colour: red\n", 4, `unknown field "colour"`},
+		{"missing code", "status_codes:\n  - description: x\n", 2, "status code is required"},
+		{"built-in code", "status_codes:\n  - code: 404\n    description: x\n", 2, "status code 404 is in the built-in range (100-600)"},
+		{"four digits", "status_codes:\n  - code: 1000\n    description: x\n", 2, "status code 1000 must have three digits"},
+		{"two digits", "status_codes:\n  - code: 42\n    description: x\n", 2, "status code 42 must have three digits"},
+		{"no description", "status_codes:\n  - code: 701\n", 2, "status code 701 has no description"},
+		{"duplicate code", "status_codes:\n  - code: 701\n    description: x\n  - code: 701\n    description: y\n", 4, "status code 701 already defined on line 2"},
+		{"codes not a list", "status_codes: 701\n", 1, "status_codes must be a list"},
+		{"built-in method", "methods:\n  - name: GET\n    description: x\n", 2, "method GET is built-in"},
+		{"iana method", "methods:\n  - name: PROPFIND\n    description: x\n", 2, "method PROPFIND is in the IANA registry"},
+		{"invalid method", "methods:\n  - name: MY METHOD\n    description: x\n", 2, `method "MY METHOD" is not a valid token`},
+		{"no method description", "methods:\n  - name: PURGE\n", 2, "method PURGE has no description"},
+		{"duplicate method", "methods:\n  - name: PURGE\n    description: x\n  - name: PURGE\n    description: y\n", 4, "method PURGE already defined on line 2"},
+		{"json line", "{\n  \"status_codes\": [\n    {\"code\": 200, \"description\": \"x\"}\n  ]\n}", 3, "status code 200 is in the built-in range (100-600)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codes.ParseCatalog(strings.NewReader(tt.in))
+			assert.ErrorIs(t, err, codes.ErrInvalidCatalog)
+
+			var cerr *codes.CatalogError
+			if assert.True(t, errors.As(err, &cerr)) {
+				assert.Equal(t, tt.line, cerr.Line)
+				assert.Contains(t, cerr.Error(), tt.msg)
+			}
+		})
+	}
+
+	// Syntax errors
+	_, err := codes.ParseCatalog(strings.NewReader("name: x\n  bad: [\n"))
+	assert.ErrorIs(t, err, codes.ErrInvalidCatalog)
+}
+
+func TestLoadCatalogAtomic(t *testing.T) {
+	// The second entry is invalid, so the first is never applied
+	in := "status_codes:\n  - code: 711\n    description: fine\n  - code: 500\n    description: bad\n"
+	_, err := codes.LoadCatalog(strings.NewReader(in))
+	assert.Error(t, err)
+	_, ok := codes.LookupStatus(711)
+	assert.False(t, ok)
+
+	// Empty catalogs are valid
+	c, err := codes.ParseCatalog(strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Empty(t, c.StatusCodes)
+}
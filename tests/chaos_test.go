@@ -0,0 +1,194 @@
+package code_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/codes/chaos"
+	"github.com/JuniorVieira99/jr_httpcodes/codes/codestest"
+	"github.com/stretchr/testify/assert"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestChaosRules(t *testing.T) {
+	injector, err := chaos.New(
+		chaos.Rule{Name: "orders-down", Path: "/api/orders*", Probability: 1, Status: codes.ServiceUnavailable, RetryAfter: 1500 * time.Millisecond},
+		chaos.Rule{Name: "no-deletes", Methods: []codes.Method{codes.DELETE}, Probability: 1, Status: codes.Forbidden},
+		chaos.Rule{Name: "canary", Header: "X-Canary", HeaderValue: "on", Probability: 1, Status: codes.TooManyRequests},
+		chaos.Rule{Name: "never", Path: "/health", Probability: 0, Status: codes.InternalServerError},
+	)
+	assert.NoError(t, err)
+	handler := injector.Middleware(okHandler)
+
+	tests := []struct {
+		method string
+		path   string
+		header string
+		code   codes.StatusCode
+		rule   string
+	}{
+		{http.MethodGet, "/api/orders/42", "", codes.ServiceUnavailable, "orders-down"},
+		{http.MethodGet, "/api/users", "", codes.OK, ""},
+		{http.MethodDelete, "/api/users/1", "", codes.Forbidden, "no-deletes"},
+		{http.MethodGet, "/api/users", "on", codes.TooManyRequests, "canary"},
+		{http.MethodGet, "/api/users", "off", codes.OK, ""},
+		{http.MethodGet, "/health", "", codes.OK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path+" "+tt.header, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				r.Header.Set("X-Canary", tt.header)
+			}
+			r.Header.Set("Accept", codes.ProblemContentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+
+			codestest.AssertStatus(t, rec, tt.code)
+			assert.Equal(t, tt.rule, rec.Header().Get("X-Chaos-Rule"))
+			if tt.rule != "" {
+				codestest.AssertProblem(t, rec, tt.code)
+			}
+		})
+	}
+
+	// Retry-After is rounded up to whole seconds
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/orders", nil))
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+
+	// Disabled injectors pass every request
+	injector.Disable()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/orders", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, injector.Enabled())
+}
+
+func TestChaosProbabilityAndDelay(t *testing.T) {
+	injector, err := chaos.New(chaos.Rule{Probability: 0.25, Status: codes.InternalServerError})
+	assert.NoError(t, err)
+	injector.Seed(1)
+	handler := injector.Middleware(okHandler)
+
+	failed := 0
+	for i := 0; i < 400; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Code == http.StatusInternalServerError {
+			failed++
+		}
+	}
+	assert.InDelta(t, 100, failed, 30)
+
+	// Delay only rules call the next handler
+	assert.NoError(t, injector.SetRules(chaos.Rule{Probability: 1, Delay: 30 * time.Millisecond}))
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+
+	// Canceled requests stop waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestChaosValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		rule chaos.Rule
+	}{
+		{"probability", chaos.Rule{Probability: 1.5, Status: 500}},
+		{"no fault", chaos.Rule{Probability: 1}},
+		{"informational", chaos.Rule{Probability: 1, Status: codes.Continue}},
+		{"path", chaos.Rule{Path: "api", Probability: 1, Status: 500}},
+		{"wildcard", chaos.Rule{Path: "/api/*/orders", Probability: 1, Status: 500}},
+		{"method", chaos.Rule{Methods: []codes.Method{"FETCH"}, Probability: 1, Status: 500}},
+		{"delay", chaos.Rule{Probability: 1, Status: 500, Delay: -time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := chaos.New(tt.rule)
+			assert.ErrorIs(t, err, chaos.ErrInvalidRule)
+		})
+	}
+}
+
+func TestChaosAdminHandler(t *testing.T) {
+	injector, err := chaos.New()
+	assert.NoError(t, err)
+	admin := injector.AdminHandler()
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Accept", "application/json")
+		admin.ServeHTTP(rec, r)
+		return rec
+	}
+
+	rec := serve(http.MethodGet, "/", "")
+	codestest.AssertStatus(t, rec, codes.OK)
+	assert.JSONEq(t, `{"enabled":true,"rules":[]}`, rec.Body.String())
+
+	rec = serve(http.MethodPut, "/", `{"enabled":false,"rules":[{"name":"slow","path":"/api/*","methods":["POST"],"probability":0.5,"status":"Service Unavailable","delay":"1.5s","retry_after":"30s"}]}`)
+	codestest.AssertStatus(t, rec, codes.OK)
+	assert.False(t, injector.Enabled())
+	assert.Equal(t, []chaos.Rule{{
+		Name: "slow", Path: "/api/*", Methods: []codes.Method{codes.POST}, Probability: 0.5,
+		Status: codes.ServiceUnavailable, Delay: 1500 * time.Millisecond, RetryAfter: 30 * time.Second,
+	}}, injector.Rules())
+
+	var state chaos.State
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &state))
+	assert.Equal(t, injector.State(), state)
+
+	rec = serve(http.MethodPost, "/?enabled=true", "")
+	codestest.AssertStatus(t, rec, codes.OK)
+	assert.True(t, injector.Enabled())
+
+	// Invalid requests keep the state
+	tests := []struct {
+		method string
+		target string
+		body   string
+		code   codes.StatusCode
+	}{
+		{http.MethodPut, "/", `{"rules":[{"probability":2,"status":500}]}`, codes.UnprocessableEntity},
+		{http.MethodPut, "/", `{"rules":[{"probability":1,"status":999}]}`, codes.BadRequest},
+		{http.MethodPut, "/", `{"rules":[{"probability":1,"delay":"soon"}]}`, codes.BadRequest},
+		{http.MethodPut, "/", `{"enabled":true,"extra":1}`, codes.BadRequest},
+		{http.MethodPut, "/", `{"rules":[{"probabilty":1,"status":500}]}`, codes.BadRequest},
+		{http.MethodPut, "/", `{`, codes.BadRequest},
+		{http.MethodPut, "/", `{"rules":[{"name":"` + strings.Repeat("a", 1<<20) + `"}]}`, codes.PayloadTooLarge},
+		{http.MethodPost, "/?enabled=maybe", "", codes.BadRequest},
+		{http.MethodPatch, "/", "", codes.MethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			rec := serve(tt.method, tt.target, tt.body)
+			codestest.AssertProblem(t, rec, tt.code)
+			assert.Len(t, injector.Rules(), 1)
+		})
+	}
+
+	rec = serve(http.MethodDelete, "/", "")
+	codestest.AssertStatus(t, rec, codes.OK)
+	assert.Empty(t, injector.Rules())
+	assert.True(t, injector.Enabled())
+}
@@ -0,0 +1,185 @@
+package code_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/internal/cli"
+	"github.com/stretchr/testify/assert"
+)
+
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	exit := cli.Run(args, &stdout, &stderr)
+	return exit, stdout.String(), stderr.String()
+}
+
+func TestLookupStatus(t *testing.T) {
+	info, ok := codes.LookupStatus(codes.ServiceUnavailable)
+	assert.True(t, ok)
+	assert.Equal(t, "Service Unavailable", info.Phrase)
+	assert.Equal(t, codes.ServerError, info.Class)
+	assert.Equal(t, "RFC 9110, Section 15.6.4", info.Reference)
+	assert.True(t, info.Retryable)
+	assert.False(t, info.Cacheable)
+
+	_, ok = codes.LookupStatus(codes.StatusCode(299))
+	assert.False(t, ok)
+
+	method, ok := codes.LookupMethod(codes.PUT)
+	assert.True(t, ok)
+	assert.True(t, method.Idempotent)
+	assert.False(t, method.Safe)
+	assert.Equal(t, "RFC 9110, Section 9.3.4", method.Reference)
+}
+
+func TestStatusSemantics(t *testing.T) {
+	assert.True(t, codes.IsCacheable(codes.OK))
+	assert.True(t, codes.IsCacheable(codes.NotFound))
+	assert.False(t, codes.IsCacheable(codes.Created))
+
+	assert.Equal(t,
+		[]codes.StatusCode{408, 425, 429, 500, 502, 503, 504},
+		codes.RetryableStatusCodes(),
+	)
+	assert.False(t, codes.IsRetryable(codes.NotFound))
+
+	// Every built-in code has a reference
+	for code := range codes.StatusPhraseMap {
+		assert.NotEmpty(t, codes.GetStatusReference(code), "missing reference: %d", code)
+	}
+}
+
+func TestParseClass(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  codes.Class
+		errExpect bool
+	}{
+		{"4xx", codes.ClientError, false},
+		{"5XX", codes.ServerError, false},
+		{"2", codes.Success, false},
+		{"redirection", codes.Redirection, false},
+		{"6xx", codes.UnknownClass, true},
+		{"", codes.UnknownClass, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			class, err := codes.ParseClass(tt.input)
+			if tt.errExpect {
+				assert.ErrorIs(t, err, codes.ErrUnknownClass)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, class)
+		})
+	}
+}
+
+func TestCLIStatus(t *testing.T) {
+	exit, out, _ := runCLI("418")
+	assert.Equal(t, cli.ExitOK, exit)
+	assert.Contains(t, out, "418 I'm a teapot")
+	assert.Contains(t, out, "Class:       4xx (Client Error)")
+	assert.Contains(t, out, "Reference:   RFC 9110, Section 15.5.19")
+
+	// Phrases spread over several args
+	exit, out, _ = runCLI("Not", "Found")
+	assert.Equal(t, cli.ExitOK, exit)
+	assert.Contains(t, out, "404 Not Found")
+
+	exit, _, errOut := runCLI("299")
+	assert.Equal(t, cli.ExitNotFound, exit)
+	assert.Contains(t, errOut, "unknown status code")
+}
+
+func TestCLIJSON(t *testing.T) {
+	exit, out, _ := runCLI("503", "--json")
+	assert.Equal(t, cli.ExitOK, exit)
+
+	var info codes.StatusInfo
+	assert.NoError(t, json.Unmarshal([]byte(out), &info))
+	assert.Equal(t, codes.ServiceUnavailable, info.Code)
+	assert.Equal(t, codes.ServerError, info.Class)
+	assert.True(t, info.Retryable)
+}
+
+func TestCLISearch(t *testing.T) {
+	exit, out, _ := runCLI("search", "timeout")
+	assert.Equal(t, cli.ExitOK, exit)
+	assert.Contains(t, out, "408")
+	assert.Contains(t, out, "504")
+
+	exit, _, _ = runCLI("search", "nothing-matches-this")
+	assert.Equal(t, cli.ExitNotFound, exit)
+
+	exit, out, _ = runCLI("--json", "search", "nothing-matches-this")
+	assert.Equal(t, cli.ExitNotFound, exit)
+	assert.Equal(t, "[]\n", out)
+}
+
+func TestCLIClass(t *testing.T) {
+	exit, out, _ := runCLI("--json", "class", "1xx")
+	assert.Equal(t, cli.ExitOK, exit)
+
+	var infos []codes.StatusInfo
+	assert.NoError(t, json.Unmarshal([]byte(out), &infos))
+	assert.Len(t, infos, 4)
+	assert.Equal(t, codes.Continue, infos[0].Code)
+
+	exit, _, _ = runCLI("class", "9xx")
+	assert.Equal(t, cli.ExitNotFound, exit)
+}
+
+func TestCLIMethod(t *testing.T) {
+	exit, out, _ := runCLI("method", "patch")
+	assert.Equal(t, cli.ExitOK, exit)
+	assert.Contains(t, out, "PATCH")
+	assert.Contains(t, out, "Idempotent:  no")
+
+	exit, out, _ = runCLI("method", "GET", "--json")
+	assert.Equal(t, cli.ExitOK, exit)
+	var info codes.MethodInfo
+	assert.NoError(t, json.Unmarshal([]byte(out), &info))
+	assert.True(t, info.Safe)
+
+	exit, _, _ = runCLI("method", "FETCH")
+	assert.Equal(t, cli.ExitNotFound, exit)
+}
+
+func TestCLIUsage(t *testing.T) {
+	exit, _, errOut := runCLI()
+	assert.Equal(t, cli.ExitUsage, exit)
+	assert.Contains(t, errOut, "Usage:")
+
+	exit, _, _ = runCLI("search")
+	assert.Equal(t, cli.ExitUsage, exit)
+
+	exit, out, _ := runCLI("--help")
+	assert.Equal(t, cli.ExitOK, exit)
+	assert.Contains(t, out, "Usage:")
+}
+
+// brokenPipe is an io.Writer whose writes fail.
+type brokenPipe struct{}
+
+func (brokenPipe) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestCLIWriteError(t *testing.T) {
+	for _, args := range [][]string{
+		{"--json", "404"},
+		{"--json", "search", "not found"},
+		{"--json", "class", "4xx"},
+		{"--json", "method", "GET"},
+	} {
+		var stderr bytes.Buffer
+		assert.Equal(t, cli.ExitSoftware, cli.Run(args, brokenPipe{}, &stderr), args)
+		assert.Contains(t, stderr.String(), "broken pipe")
+	}
+}
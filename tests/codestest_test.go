@@ -0,0 +1,134 @@
+package code_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/codes/codestest"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingT records the failures of the assertions.
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func problemHandler(w http.ResponseWriter, r *http.Request) {
+	codes.DefaultErrorPage.Render(w, r, codes.NotFound, codes.NewHTTPError(codes.NotFound, "Order 42 does not exist", nil))
+}
+
+func TestAssertStatus(t *testing.T) {
+	rec := httptest.NewRecorder()
+	http.Error(rec, `{"detail": "name is required"}`, http.StatusBadRequest)
+
+	ft := &recordingT{}
+	assert.True(t, codestest.AssertStatus(ft, rec, codes.BadRequest))
+	assert.Empty(t, ft.errors)
+
+	assert.False(t, codestest.AssertStatus(ft, rec, codes.Created))
+	assert.Equal(t, []string{"unexpected status code\n" +
+		"    expected: 201 Created (Resource created successfully and location provided)\n" +
+		"    actual:   400 Bad Request (Server cannot process request due to client error)\n" +
+		`    body:     {"detail": "name is required"}`}, ft.errors)
+
+	// Client responses can still be read after the assertion
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		io.WriteString(w, "queued")
+	}))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	ft = &recordingT{}
+	assert.True(t, codestest.AssertStatus(ft, resp, codes.Accepted))
+	assert.False(t, codestest.AssertStatus(ft, resp, codes.OK))
+	assert.Contains(t, ft.errors[0], "body:     queued")
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "queued", string(body))
+}
+
+func TestAssertClass(t *testing.T) {
+	tests := []struct {
+		code  int
+		class codes.Class
+		ok    bool
+		msg   string
+	}{
+		{404, codes.ClientError, true, ""},
+		{201, codes.Success, true, ""},
+		{503, codes.ClientError, false, "expected: 4xx Client Error\n    actual:   503 Service Unavailable"},
+		{204, codes.Redirection, false, "body:     (empty)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.code), func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.code, Header: http.Header{}}
+			ft := &recordingT{}
+			assert.Equal(t, tt.ok, codestest.AssertClass(ft, resp, tt.class))
+			if tt.ok {
+				assert.Empty(t, ft.errors)
+				return
+			}
+			assert.Len(t, ft.errors, 1)
+			assert.Contains(t, ft.errors[0], tt.msg)
+		})
+	}
+}
+
+func TestAssertProblem(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+	r.Header.Set("Accept", codes.ProblemContentType)
+	rec := httptest.NewRecorder()
+	problemHandler(rec, r)
+
+	ft := &recordingT{}
+	p := codestest.AssertProblem(ft, rec, codes.NotFound)
+	assert.Empty(t, ft.errors)
+	if assert.NotNil(t, p) {
+		assert.Equal(t, "Order 42 does not exist", p.Detail)
+	}
+
+	// Wrong status
+	assert.Nil(t, codestest.AssertProblem(ft, rec, codes.Gone))
+	assert.Contains(t, ft.errors[0], "expected: 410 Gone")
+
+	// HTML body
+	r.Header.Set("Accept", "text/html")
+	rec = httptest.NewRecorder()
+	problemHandler(rec, r)
+	ft = &recordingT{}
+	assert.Nil(t, codestest.AssertProblem(ft, rec, codes.NotFound))
+	assert.Contains(t, ft.errors[0], "unexpected content type")
+
+	// Problem status not matching the response
+	rec = httptest.NewRecorder()
+	rec.Header().Set("Content-Type", codes.ProblemContentType)
+	rec.WriteHeader(http.StatusNotFound)
+	rec.WriteString(`{"status":410,"title":"Gone"}`)
+	ft = &recordingT{}
+	assert.Nil(t, codestest.AssertProblem(ft, rec, codes.NotFound))
+	assert.Contains(t, ft.errors[0], "unexpected problem status")
+
+	// Long bodies are cut
+	defer func(n int) { codestest.BodyExcerpt = n }(codestest.BodyExcerpt)
+	codestest.BodyExcerpt = 10
+	rec = httptest.NewRecorder()
+	rec.WriteHeader(http.StatusOK)
+	rec.WriteString(strings.Repeat("a", 20))
+	ft = &recordingT{}
+	codestest.AssertStatus(ft, rec, codes.Created)
+	assert.Contains(t, ft.errors[0], "body:     aaaaaaaaaa...")
+}
@@ -0,0 +1,157 @@
+package code_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"sync"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+// getWithTrace performs a GET request and collects every 1xx response received.
+func getWithTrace(t *testing.T, url string) (*http.Response, []textproto.MIMEHeader) {
+	var (
+		mu    sync.Mutex
+		infos []textproto.MIMEHeader
+	)
+
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			if code == int(codes.EarlyHints) {
+				mu.Lock()
+				infos = append(infos, header)
+				mu.Unlock()
+			}
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	assert.NoError(t, err)
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	return resp, infos
+}
+
+func TestEarlyHintsConstant(t *testing.T) {
+	assert.Equal(t, codes.StatusCode(103), codes.EarlyHints)
+	assert.True(t, codes.IsInformational(codes.EarlyHints))
+	assert.Equal(t, string(codes.EarlyHintsDesc), codes.GetStatusInfo(codes.EarlyHints))
+}
+
+func TestHintString(t *testing.T) {
+	tests := []struct {
+		name     string
+		hint     codes.Hint
+		expected string
+	}{
+		{"Default Rel", codes.Hint{URL: "/app.css", As: "style"}, "</app.css>; rel=preload; as=style"},
+		{"Preconnect", codes.Hint{URL: "https://cdn.example.com", Rel: "preconnect"}, "<https://cdn.example.com>; rel=preconnect"},
+		{
+			"Font",
+			codes.Hint{URL: "/font.woff2", As: "font", Type: "font/woff2", CrossOrigin: "anonymous"},
+			"</font.woff2>; rel=preload; as=font; type=\"font/woff2\"; crossorigin=anonymous",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.hint.String())
+		})
+	}
+}
+
+func TestWriteEarlyHints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, codes.WriteEarlyHints(w, codes.Hint{URL: "/app.css", As: "style"}))
+		assert.NoError(t, codes.WriteEarlyHints(w, codes.Hint{URL: "/app.js", As: "script"}))
+		w.WriteHeader(int(codes.OK))
+	}))
+	defer server.Close()
+
+	resp, infos := getWithTrace(t, server.URL)
+	assert.Equal(t, int(codes.OK), resp.StatusCode)
+	assert.Len(t, infos, 2)
+	assert.Equal(t, []string{"</app.css>; rel=preload; as=style"}, infos[0].Values("Link"))
+	assert.Contains(t, infos[1].Values("Link"), "</app.js>; rel=preload; as=script")
+
+	// Final response repeats the hints
+	assert.Len(t, resp.Header.Values("Link"), 2)
+}
+
+func TestWriteEarlyHintsErrors(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	assert.ErrorIs(t, codes.WriteEarlyHints(rec), codes.ErrNoHints)
+	assert.ErrorIs(t, codes.WriteEarlyHints(rec, codes.Hint{URL: "/a.css"}, codes.Hint{}), codes.ErrEmptyHintURL)
+
+	// Nothing written on error
+	assert.Empty(t, rec.Header().Values("Link"))
+}
+
+func TestHintManifestLookup(t *testing.T) {
+	manifest := codes.HintManifest{
+		"/":            {{URL: "/home.css"}},
+		"/docs/*":      {{URL: "/docs.css"}},
+		"/docs/api/*":  {{URL: "/api.css"}},
+		"/docs/api/v1": {{URL: "/v1.css"}},
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/", "/home.css"},
+		{"/docs/intro", "/docs.css"},
+		{"/docs/api/v2", "/api.css"},
+		{"/docs/api/v1", "/v1.css"},
+		{"/other", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			hints := manifest.Lookup(tt.path)
+			if tt.expected == "" {
+				assert.Empty(t, hints)
+				return
+			}
+			assert.Equal(t, tt.expected, hints[0].URL)
+		})
+	}
+}
+
+func TestEarlyHintsMiddleware(t *testing.T) {
+	manifest := codes.HintManifest{
+		"/page": {{URL: "/page.css", As: "style"}, {URL: "/page.js", As: "script"}},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(codes.OK))
+	})
+	server := httptest.NewServer(codes.EarlyHintsMiddleware(manifest)(next))
+	defer server.Close()
+
+	// Route with hints
+	resp, infos := getWithTrace(t, server.URL+"/page")
+	assert.Equal(t, int(codes.OK), resp.StatusCode)
+	assert.Len(t, infos, 1)
+	assert.Len(t, infos[0].Values("Link"), 2)
+
+	// Route without hints
+	resp, infos = getWithTrace(t, server.URL+"/none")
+	assert.Equal(t, int(codes.OK), resp.StatusCode)
+	assert.Empty(t, infos)
+
+	// Non GET requests are untouched
+	resp, err := http.Post(server.URL+"/page", "text/plain", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, resp.Header.Values("Link"))
+}
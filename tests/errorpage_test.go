@@ -0,0 +1,248 @@
+package code_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemJSON(t *testing.T) {
+	p := codes.NewProblem(codes.NotFound, "Order 42 does not exist")
+	p.Extensions = map[string]interface{}{"order": 42, "title": "ignored"}
+
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"about:blank","title":"Not Found","status":404,"detail":"Order 42 does not exist","order":42}`, string(b))
+	assert.Equal(t, "404 Not Found: Order 42 does not exist", p.Error())
+
+	var got codes.Problem
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, codes.NotFound, got.Status)
+	assert.Equal(t, "Order 42 does not exist", got.Detail)
+	assert.Equal(t, map[string]interface{}{"order": float64(42)}, got.Extensions)
+
+	// Wrongly typed members are ignored and the type defaults to about:blank
+	assert.NoError(t, json.Unmarshal([]byte(`{"status":"bad","title":"Oops"}`), &got))
+	assert.Equal(t, codes.Problem{Type: "about:blank", Title: "Oops"}, got)
+	assert.Equal(t, codes.InternalServerError, got.StatusCode())
+}
+
+func TestHTTPError(t *testing.T) {
+	cause := errors.New("sql: no rows")
+	err := fmt.Errorf("loading order: %w", codes.NewHTTPError(codes.NotFound, "Order not found", cause))
+
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "loading order: 404 Not Found: Order not found: sql: no rows", err.Error())
+
+	p, ok := codes.ProblemOf(err)
+	assert.True(t, ok)
+	assert.Equal(t, "Order not found", p.Detail)
+	assert.Equal(t, codes.NotFound, p.Status)
+
+	_, ok = codes.ProblemOf(cause)
+	assert.False(t, ok)
+}
+
+func TestErrorPageNegotiation(t *testing.T) {
+	tests := []struct {
+		accept      string
+		contentType string
+		contains    string
+	}{
+		{"", "text/html; charset=utf-8", "<h1>"},
+		{"text/html,application/xhtml+xml,*/*;q=0.8", "text/html; charset=utf-8", "Not Found"},
+		{"application/json", "application/json", `"status":404`},
+		{"application/problem+json", codes.ProblemContentType, `"instance":"/orders/42"`},
+		{"text/plain", "text/plain; charset=utf-8", "404 Not Found\nWe couldn't find the page you were looking for."},
+		{"text/*;q=0.5, application/json;q=0.9", "application/json", `"title":"Not Found"`},
+		{"*/*;q=0.1, text/html;q=0", codes.ProblemContentType, `"type":"about:blank"`},
+		{"image/png", "text/html; charset=utf-8", "404"},
+		{"text/html;q=0", "text/plain; charset=utf-8", "404 Not Found"},
+		{"*/*;q=0", "text/plain; charset=utf-8", "404 Not Found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+			r.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+
+			codes.DefaultErrorPage.Render(w, r, codes.NotFound, nil)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, []string{"Accept", "Accept-Language"}, w.Header().Values("Vary"))
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			assert.Contains(t, w.Body.String(), tt.contains)
+		})
+	}
+}
+
+func TestErrorPageErrors(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+	r.Header.Set("Accept", "application/problem+json")
+	r.Header.Set("X-Request-Id", "req-1")
+
+	// HTTPError detail is shown, its cause is not
+	w := httptest.NewRecorder()
+	err := codes.NewHTTPError(codes.Conflict, "Order already paid", errors.New("secret"))
+	codes.DefaultErrorPage.Render(w, r, 0, err)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret")
+	var p codes.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	assert.Equal(t, "Order already paid", p.Detail)
+	assert.Equal(t, "/orders/42", p.Instance)
+
+	// Problem extensions are kept and the code argument wins
+	w = httptest.NewRecorder()
+	problem := &codes.Problem{Type: "https://example.com/out-of-stock", Title: "Out of stock", Detail: "No more items", Extensions: map[string]interface{}{"sku": "a1"}}
+	codes.DefaultErrorPage.Render(w, r, codes.UnprocessableEntity, problem)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{"type":"https://example.com/out-of-stock","title":"Out of stock","status":422,"detail":"No more items","instance":"/orders/42","sku":"a1"}`, w.Body.String())
+	assert.Empty(t, problem.Instance, "the problem of the caller is not modified")
+
+	// Request ID in HTML pages, escaped
+	r.Header.Set("Accept", "text/html")
+	r.Header.Set("X-Request-Id", "<script>")
+	w = httptest.NewRecorder()
+	codes.DefaultErrorPage.Render(w, r, codes.InternalServerError, errors.New("secret"))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "&lt;script&gt;")
+	assert.Contains(t, w.Body.String(), "class-5xx")
+	assert.NotContains(t, w.Body.String(), "secret")
+}
+
+func TestErrorPageOverrides(t *testing.T) {
+	fsys := fstest.MapFS{
+		"404.html": {Data: []byte(`<p>{{printf "%d" .Code}} custom {{.Values.brand}}</p>`)},
+		"5xx.txt":  {Data: []byte(`{{.Phrase}} on {{.Values.brand}}`)},
+	}
+	page, err := codes.NewErrorPage(fsys)
+	assert.NoError(t, err)
+	page.Values = map[string]string{"brand": "Shop"}
+
+	tests := []struct {
+		code   codes.StatusCode
+		accept string
+		want   string
+	}{
+		{codes.NotFound, "text/html", "<p>404 custom Shop</p>"},
+		{codes.BadGateway, "text/plain", "Bad Gateway on Shop"},
+		// Embedded templates are kept for other codes
+		{codes.Gone, "text/plain", "410 Gone\nThis page is no longer available."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			page.Render(w, r, tt.code, nil)
+			assert.Equal(t, tt.want, strings.TrimSpace(w.Body.String()))
+		})
+	}
+
+	_, err = codes.NewErrorPage(fstest.MapFS{"error.html": {Data: []byte("{{.Code")}})
+	assert.Error(t, err)
+}
+
+func TestErrorPageInvalidStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		code codes.StatusCode
+		err  error
+	}{
+		{"http error below 100", 0, codes.NewHTTPError(42, "x", nil)},
+		{"http error above 999", 0, codes.NewHTTPError(1000, "x", nil)},
+		{"redirect problem", 0, &codes.Problem{Status: codes.Found}},
+		{"code below 100", 42, nil},
+		{"code above 999", 1000, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			assert.NotPanics(t, func() {
+				codes.DefaultErrorPage.Render(w, httptest.NewRequest(http.MethodGet, "/", nil), tt.code, tt.err)
+			})
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+		})
+	}
+}
+
+func TestErrorPageUpstreamProblem(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+	r.Header.Set("Accept", "application/problem+json")
+
+	// The problem of an upstream response is never shown
+	upstream := &codes.ResponseError{
+		Code:    codes.UnprocessableEntity,
+		Problem: &codes.Problem{Status: 1000, Detail: "inventory db down", Instance: "/internal/stock", Extensions: map[string]interface{}{"host": "db-1"}},
+	}
+	w := httptest.NewRecorder()
+	assert.NotPanics(t, func() {
+		codes.DefaultErrorPage.Render(w, r, 0, fmt.Errorf("checking stock: %w", upstream))
+	})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "inventory db down")
+	assert.NotContains(t, w.Body.String(), "/internal/stock")
+	assert.NotContains(t, w.Body.String(), "db-1")
+
+	// An application error wrapping it shows its own problem only
+	w = httptest.NewRecorder()
+	codes.DefaultErrorPage.Render(w, r, 0, codes.NewHTTPError(codes.BadGateway, "Inventory unavailable", upstream))
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Contains(t, w.Body.String(), "Inventory unavailable")
+	assert.NotContains(t, w.Body.String(), "inventory db down")
+}
+
+func TestErrorPageNilRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	assert.NotPanics(t, func() {
+		codes.DefaultErrorPage.Render(w, nil, codes.BadGateway, nil)
+	})
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "Bad Gateway")
+}
+
+func TestErrorPageHandler(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodGet, "/errors/503", http.StatusServiceUnavailable},
+		{http.MethodGet, "/errors/429", http.StatusTooManyRequests},
+		{http.MethodGet, "/errors/abc", http.StatusNotFound},
+		{http.MethodGet, "/errors/42", http.StatusNotFound},
+		{http.MethodGet, "/errors/101", http.StatusNotFound},
+		{http.MethodGet, "/errors/302", http.StatusNotFound},
+		{http.MethodHead, "/errors/500", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			codes.DefaultErrorPage.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.code, w.Code)
+			if tt.method == http.MethodHead {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	codes.DefaultErrorPage.Handler(codes.ServiceUnavailable).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/maintenance", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
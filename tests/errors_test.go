@@ -0,0 +1,103 @@
+package code_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+type validationError struct{ field string }
+
+func (e *validationError) Error() string { return "invalid " + e.field }
+
+func TestFromErrorStdlib(t *testing.T) {
+	_, openErr := os.Open("testdata/missing.yaml")
+	syntaxErr := json.Unmarshal([]byte("{"), &struct{}{})
+	typeErr := json.Unmarshal([]byte(`{"a":"x"}`), &struct{ A int }{})
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too large"))
+	_, maxBytesErr := io.ReadAll(http.MaxBytesReader(httptest.NewRecorder(), r.Body, 2))
+
+	tests := []struct {
+		name string
+		err  error
+		want codes.StatusCode
+	}{
+		{"nil", nil, codes.OK},
+		{"deadline", context.DeadlineExceeded, codes.GatewayTimeout},
+		{"wrapped deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), codes.GatewayTimeout},
+		{"canceled", context.Canceled, codes.ClientClosedRequest},
+		{"not exist", openErr, codes.NotFound},
+		{"fs not exist", fs.ErrNotExist, codes.NotFound},
+		{"permission", &fs.PathError{Op: "open", Path: "/root", Err: os.ErrPermission}, codes.Forbidden},
+		{"max bytes", maxBytesErr, codes.PayloadTooLarge},
+		{"json syntax", syntaxErr, codes.BadRequest},
+		{"json type", typeErr, codes.BadRequest},
+		{"net timeout", &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, codes.GatewayTimeout},
+		{"os deadline", os.ErrDeadlineExceeded, codes.GatewayTimeout},
+		{"http error", fmt.Errorf("x: %w", codes.NewHTTPError(codes.Conflict, "", context.Canceled)), codes.Conflict},
+		{"problem", codes.NewProblem(codes.TooManyRequests, ""), codes.TooManyRequests},
+		{"invalid http error", codes.NewHTTPError(42, "", nil), codes.InternalServerError},
+		{"zero http error", codes.NewHTTPError(0, "", nil), codes.InternalServerError},
+		{"too large http error", codes.NewHTTPError(1000, "", nil), codes.InternalServerError},
+		{"invalid problem", &codes.Problem{Status: 600}, codes.InternalServerError},
+		{"unknown", errors.New("boom"), codes.InternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, codes.FromError(tt.err))
+		})
+	}
+}
+
+func TestClientClosedRequest(t *testing.T) {
+	assert.True(t, codes.IsValidStatusCode(codes.ClientClosedRequest))
+	assert.Equal(t, "Client Closed Request", codes.GetStatusPhrase(codes.ClientClosedRequest))
+	assert.Equal(t, string(codes.ClientClosedRequestDesc), codes.GetStatusInfo(codes.ClientClosedRequest))
+	assert.Equal(t, codes.ClientError, codes.ClientClosedRequest.Class())
+}
+
+func TestFromErrorRegistered(t *testing.T) {
+	errOrderPaid := errors.New("order already paid")
+
+	removePaid := codes.RegisterError(errOrderPaid, codes.Conflict)
+	removeType := codes.RegisterErrorType[*validationError](codes.UnprocessableEntity)
+	removeFunc := codes.RegisterErrorFunc(func(err error) (codes.StatusCode, bool) {
+		return codes.Gone, strings.Contains(err.Error(), "archived")
+	})
+
+	assert.Equal(t, codes.Conflict, codes.FromError(fmt.Errorf("paying: %w", errOrderPaid)))
+	assert.Equal(t, codes.UnprocessableEntity, codes.FromError(fmt.Errorf("body: %w", &validationError{"name"})))
+	assert.Equal(t, codes.Gone, codes.FromError(errors.New("order archived")))
+
+	// Registered mappings win over the standard library ones, most recent first
+	removeNotExist := codes.RegisterError(fs.ErrNotExist, codes.Gone)
+	assert.Equal(t, codes.Gone, codes.FromError(fs.ErrNotExist))
+	removeNotExist()
+	assert.Equal(t, codes.NotFound, codes.FromError(fs.ErrNotExist))
+
+	// Invalid mapped codes fall back to 500
+	removeInvalid := codes.RegisterError(errOrderPaid, 42)
+	assert.Equal(t, codes.InternalServerError, codes.FromError(errOrderPaid))
+	removeInvalid()
+
+	removePaid()
+	removeType()
+	removeFunc()
+	removeFunc()
+	assert.Equal(t, codes.InternalServerError, codes.FromError(errOrderPaid))
+	assert.Equal(t, codes.InternalServerError, codes.FromError(&validationError{"name"}))
+}
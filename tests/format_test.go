@@ -0,0 +1,81 @@
+package code_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCodeFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%d", "404"},
+		{"%5d", "  404"},
+		{"%s", "Not Found"},
+		{"%-12s|", "Not Found   |"},
+		{"%q", `"Not Found"`},
+		{"%v", "404 -> Requested resource could not be found"},
+		{"%+v", "404 Not Found [4xx Client Error] Requested resource could not be found"},
+		{"%x", "194"},
+		{"%t", "%!t(codes.StatusCode=404)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fmt.Sprintf(tt.format, codes.NotFound))
+		})
+	}
+
+	// %v matches String
+	assert.Equal(t, codes.OK.String(), fmt.Sprint(codes.OK))
+}
+
+func TestMethodFormat(t *testing.T) {
+	tests := []struct {
+		method   codes.Method
+		format   string
+		expected string
+	}{
+		{codes.PATCH, "%s", "PATCH"},
+		{codes.PATCH, "%-7s|", "PATCH  |"},
+		{codes.PATCH, "%q", `"PATCH"`},
+		{codes.PATCH, "%v", "PATCH -> Partially update data on server"},
+		{codes.PATCH, "%+v", "PATCH [unsafe, non-idempotent] Partially update data on server"},
+		{codes.GET, "%+v", "GET [safe, idempotent] Retrieve data from server"},
+		{codes.PATCH, "%d", "%!d(codes.Method=PATCH)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fmt.Sprintf(tt.format, tt.method))
+		})
+	}
+}
+
+func TestFprint(t *testing.T) {
+	var buf bytes.Buffer
+
+	_, err := codes.NotFound.Fprint(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, codes.NotFound.String()+"\n", buf.String())
+
+	buf.Reset()
+	_, err = codes.GET.Fprint(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, codes.GET.String()+"\n", buf.String())
+
+	buf.Reset()
+	_, err = codes.FprintStatusCodeMap(&buf, map[codes.StatusCode]codes.Description{codes.OK: codes.OKDesc})
+	assert.NoError(t, err)
+	assert.Equal(t, "200 -> "+string(codes.OKDesc)+"\n\n", buf.String())
+
+	buf.Reset()
+	_, err = codes.FprintMethodMap(&buf, map[codes.Method]codes.Description{codes.GET: codes.GETDesc})
+	assert.NoError(t, err)
+	assert.Equal(t, codes.GET.String()+"\n\n", buf.String())
+}
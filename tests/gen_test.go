@@ -0,0 +1,171 @@
+package code_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/internal/gen"
+	"github.com/stretchr/testify/assert"
+)
+
+const genDataDir = "../internal/gen/data"
+
+// writeGenData copies the vendored data to a temporary directory and
+// replaces the given files.
+func writeGenData(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"iana/http-status-codes.csv",
+		"iana/methods.csv",
+		"overlay/status-codes.csv",
+		"overlay/methods.csv",
+	} {
+		data, err := os.ReadFile(filepath.Join(genDataDir, name))
+		assert.NoError(t, err)
+		if content, ok := files[name]; ok {
+			data = []byte(content)
+		}
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0o644))
+	}
+	return dir
+}
+
+func TestGeneratedCodeUpToDate(t *testing.T) {
+	assert.NoError(t, gen.Check(genDataDir, "../codes/codes_gen.go"))
+}
+
+func TestGeneratedTables(t *testing.T) {
+	reg, err := gen.Load(genDataDir)
+	assert.NoError(t, err)
+
+	// Every generated status code is registered with its phrase and reference
+	count := 0
+	for _, class := range reg.Classes {
+		for _, s := range class.Statuses {
+			code := codes.StatusCode(s.Code)
+			assert.Equal(t, s.Description, codes.GetStatusInfo(code))
+			assert.Equal(t, s.Phrase, codes.GetStatusPhrase(code))
+			assert.Equal(t, s.Reference, codes.GetStatusReference(code))
+			count++
+		}
+	}
+	assert.Equal(t, count, len(codes.StatusPhraseMap))
+
+	// References are reformatted, overlay phrases win over the registry
+	assert.Equal(t, "RFC 9110, Section 15.5.5", codes.GetStatusReference(codes.NotFound))
+	assert.Equal(t, "RFC 2774; status-change-http-experiments-to-historic", codes.GetStatusReference(codes.NotExtended))
+	assert.Equal(t, "Not Extended", codes.GetStatusPhrase(codes.NotExtended))
+	assert.Equal(t, "I'm a teapot", codes.GetStatusPhrase(codes.Teapot))
+
+	// Codes outside the registry set their own phrase and reference
+	assert.Equal(t, "Client Closed Request", codes.GetStatusPhrase(codes.ClientClosedRequest))
+	assert.Equal(t, "nginx, ngx_http_request.h", codes.GetStatusReference(codes.ClientClosedRequest))
+
+	// Method semantics cover the whole registry
+	assert.Len(t, reg.Methods, 9)
+	assert.True(t, codes.IsSafeMethod(codes.Method("PROPFIND")))
+	assert.True(t, codes.IsIdempotentMethod(codes.Method("MKCOL")))
+	assert.False(t, codes.IsIdempotentMethod(codes.Method("LOCK")))
+}
+
+func TestGenerateDrift(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "codes_gen.go")
+	src, err := gen.Generate(genDataDir)
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(out, src, 0o644))
+	assert.NoError(t, gen.Check(genDataDir, out))
+
+	// Hand edit
+	assert.NoError(t, os.WriteFile(out, append(src, []byte("\n// edited\n")...), 0o644))
+	assert.Error(t, gen.Check(genDataDir, out))
+}
+
+func TestGenerateValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		errMsg  string
+	}{
+		{
+			"Unknown Status Code",
+			"overlay/status-codes.csv",
+			"Value,Name,Phrase,Description,Reference\n299,Custom,,Custom code,\n",
+			"overlay/status-codes.csv:2: status code 299 is not in the IANA registry, set a Phrase and a Reference",
+		},
+		{
+			"Line After Multiline Field",
+			"overlay/status-codes.csv",
+			"Value,Name,Phrase,Description,Reference\n200,OK,,\"Fine,\nreally\",\n299,Custom,,Custom code,\n",
+			"overlay/status-codes.csv:4: status code 299 is not in the IANA registry",
+		},
+		{
+			"Unregistered Without Reference",
+			"overlay/status-codes.csv",
+			"Value,Name,Phrase,Description,Reference\n499,ClientClosedRequest,Client Closed Request,Canceled,\n",
+			"set a Phrase and a Reference",
+		},
+		{
+			"Unused Without Phrase",
+			"overlay/status-codes.csv",
+			"Value,Name,Phrase,Description,Reference\n306,SwitchProxy,,Unused,\n",
+			"set a Phrase",
+		},
+		{
+			"Invalid Name",
+			"overlay/status-codes.csv",
+			"Value,Name,Phrase,Description,Reference\n200,ok-status,,Fine,\n",
+			"invalid Go name",
+		},
+		{
+			"Duplicate Name",
+			"overlay/status-codes.csv",
+			"Value,Name,Phrase,Description,Reference\n200,OK,,Fine,\n201,OK,,Fine,\n",
+			"duplicate Go name",
+		},
+		{
+			"Empty Description",
+			"overlay/status-codes.csv",
+			"Value,Name,Phrase,Description,Reference\n200,OK,,,\n",
+			"empty description",
+		},
+		{
+			"Unsorted",
+			"overlay/status-codes.csv",
+			"Value,Name,Phrase,Description,Reference\n201,Created,,Fine,\n200,OK,,Fine,\n",
+			"listed after",
+		},
+		{
+			"Unknown Method",
+			"overlay/methods.csv",
+			"Method,Description\nFETCH,Fetch\n",
+			"method \"FETCH\" is not in the IANA registry",
+		},
+		{
+			"Bad Semantics",
+			"iana/methods.csv",
+			"Method Name,Safe,Idempotent,Reference\nGET,maybe,yes,[RFC9110]\n",
+			"expected yes or no",
+		},
+		{
+			"Wrong Columns",
+			"iana/http-status-codes.csv",
+			"Value,Description\n200,OK\n",
+			"wrong number of fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeGenData(t, map[string]string{tt.file: tt.content})
+			_, err := gen.Generate(dir)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package code_test
+
+import (
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/codes/grpcmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGRPCRoundTrip(t *testing.T) {
+	tests := []struct {
+		code      grpcmap.Code
+		status    codes.StatusCode
+		roundTrip grpcmap.Code
+	}{
+		{grpcmap.OK, codes.OK, grpcmap.OK},
+		{grpcmap.Canceled, codes.ClientClosedRequest, grpcmap.Canceled},
+		{grpcmap.Unknown, codes.InternalServerError, grpcmap.Internal},
+		{grpcmap.InvalidArgument, codes.BadRequest, grpcmap.InvalidArgument},
+		{grpcmap.DeadlineExceeded, codes.GatewayTimeout, grpcmap.DeadlineExceeded},
+		{grpcmap.NotFound, codes.NotFound, grpcmap.NotFound},
+		{grpcmap.AlreadyExists, codes.Conflict, grpcmap.Aborted},
+		{grpcmap.PermissionDenied, codes.Forbidden, grpcmap.PermissionDenied},
+		{grpcmap.ResourceExhausted, codes.TooManyRequests, grpcmap.ResourceExhausted},
+		{grpcmap.FailedPrecondition, codes.BadRequest, grpcmap.InvalidArgument},
+		{grpcmap.Aborted, codes.Conflict, grpcmap.Aborted},
+		{grpcmap.OutOfRange, codes.BadRequest, grpcmap.InvalidArgument},
+		{grpcmap.Unimplemented, codes.NotImplemented, grpcmap.Unimplemented},
+		{grpcmap.Internal, codes.InternalServerError, grpcmap.Internal},
+		{grpcmap.Unavailable, codes.ServiceUnavailable, grpcmap.Unavailable},
+		{grpcmap.DataLoss, codes.InternalServerError, grpcmap.Internal},
+		{grpcmap.Unauthenticated, codes.Unauthorized, grpcmap.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			status := grpcmap.FromGRPC(tt.code)
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.roundTrip, grpcmap.ToGRPC(status))
+
+			// Codes that map back to themselves are stable both ways
+			if tt.roundTrip == tt.code {
+				assert.Equal(t, status, grpcmap.FromGRPC(grpcmap.ToGRPC(status)))
+			}
+		})
+	}
+}
+
+func TestGRPCFallbacks(t *testing.T) {
+	tests := []struct {
+		status codes.StatusCode
+		want   grpcmap.Code
+	}{
+		{codes.Created, grpcmap.OK},
+		{codes.Teapot, grpcmap.FailedPrecondition},
+		{codes.RequestTimeout, grpcmap.DeadlineExceeded},
+		{codes.BadGateway, grpcmap.Unavailable},
+		{codes.LoopDetected, grpcmap.Unknown},
+		{codes.MovedPermanently, grpcmap.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, grpcmap.ToGRPC(tt.status))
+		})
+	}
+
+	assert.Equal(t, codes.InternalServerError, grpcmap.FromGRPC(42))
+}
+
+func TestGRPCOverrides(t *testing.T) {
+	m := grpcmap.NewMapper()
+
+	assert.NoError(t, m.Override(codes.Conflict, grpcmap.AlreadyExists))
+	assert.Equal(t, grpcmap.AlreadyExists, m.ToGRPC(codes.Conflict))
+	assert.Equal(t, codes.Conflict, m.FromGRPC(grpcmap.AlreadyExists))
+
+	assert.NoError(t, m.SetFromGRPC(grpcmap.FailedPrecondition, codes.PreconditionFailed))
+	assert.Equal(t, codes.PreconditionFailed, m.FromGRPC(grpcmap.FailedPrecondition))
+	assert.Equal(t, grpcmap.FailedPrecondition, m.ToGRPC(codes.PreconditionFailed))
+
+	assert.NoError(t, m.SetToGRPC(codes.Teapot, grpcmap.Unimplemented))
+	assert.Equal(t, grpcmap.Unimplemented, m.ToGRPC(codes.Teapot))
+	assert.Equal(t, codes.NotImplemented, m.FromGRPC(grpcmap.Unimplemented))
+
+	assert.ErrorIs(t, m.Override(codes.OK, 17), grpcmap.ErrInvalidCode)
+	assert.ErrorIs(t, m.SetToGRPC(42, grpcmap.OK), grpcmap.ErrInvalidStatus)
+
+	// Overrides of a Mapper do not change Default
+	assert.Equal(t, grpcmap.Aborted, grpcmap.ToGRPC(codes.Conflict))
+
+	m.Reset()
+	assert.Equal(t, grpcmap.Aborted, m.ToGRPC(codes.Conflict))
+	assert.Equal(t, codes.BadRequest, m.FromGRPC(grpcmap.FailedPrecondition))
+}
+
+func TestGRPCParseCode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  grpcmap.Code
+		err   bool
+	}{
+		{"NotFound", grpcmap.NotFound, false},
+		{"RESOURCE_EXHAUSTED", grpcmap.ResourceExhausted, false},
+		{"cancelled", grpcmap.Canceled, false},
+		{" 14 ", grpcmap.Unavailable, false},
+		{"17", 0, true},
+		{"Missing", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			code, err := grpcmap.ParseCode(tt.input)
+			if tt.err {
+				assert.ErrorIs(t, err, grpcmap.ErrInvalidCode)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+
+	assert.Equal(t, "Code(17)", grpcmap.Code(17).String())
+	text, err := grpcmap.DataLoss.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "DataLoss", string(text))
+}
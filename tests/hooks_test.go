@@ -0,0 +1,133 @@
+package code_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryHooks(t *testing.T) {
+	var registered, deleted []codes.Mutation
+	removeRegister := codes.OnRegister(func(m codes.Mutation) { registered = append(registered, m) })
+	removeDelete := codes.OnDelete(func(m codes.Mutation) { deleted = append(deleted, m) })
+
+	codes.RegisterStatusCode(741, "First")
+	codes.RegisterStatusCode(741, "Second")
+	codes.RegisterStatusCode(404, "Ignored")
+	codes.DeleteStatusCode(741)
+	codes.DeleteStatusCode(741)
+	codes.RegisterMethod("HOOKED", "Hooked method")
+	codes.DeleteMethod("HOOKED")
+
+	assert.Len(t, registered, 3)
+	assert.Len(t, deleted, 2)
+
+	first, second := registered[0], registered[1]
+	assert.Equal(t, codes.MutationRegister, first.Kind)
+	assert.Equal(t, codes.StatusCode(741), first.Code)
+	assert.False(t, first.Existed)
+	assert.Equal(t, codes.Description("First"), first.New)
+	assert.True(t, second.Existed)
+	assert.Equal(t, codes.Description("First"), second.Old)
+	assert.Equal(t, codes.Description("Second"), second.New)
+	assert.Equal(t, codes.Method("HOOKED"), registered[2].Method)
+
+	assert.Equal(t, codes.MutationDelete, deleted[0].Kind)
+	assert.Equal(t, codes.Description("Second"), deleted[0].Old)
+	assert.Equal(t, codes.Method("HOOKED"), deleted[1].Method)
+
+	// The caller is the test, not the codes package
+	assert.Equal(t, "hooks_test.go", filepath.Base(first.Caller.File))
+	assert.True(t, strings.HasSuffix(first.Caller.Function, "TestRegistryHooks"))
+	assert.False(t, first.Time.IsZero())
+	assert.Contains(t, second.String(), `register 741 "First" -> "Second" at `)
+
+	// Removed hooks are not called
+	removeRegister()
+	removeDelete()
+	codes.RegisterStatusCode(741, "Third")
+	codes.DeleteStatusCode(741)
+	assert.Len(t, registered, 3)
+	assert.Len(t, deleted, 2)
+}
+
+func TestRegistryHooksCatalog(t *testing.T) {
+	var muts []codes.Mutation
+	defer codes.OnRegister(func(m codes.Mutation) { muts = append(muts, m) })()
+	defer codes.OnDelete(func(m codes.Mutation) { muts = append(muts, m) })()
+
+	c, err := codes.LoadCatalog(strings.NewReader("status_codes:\n  - code: 742\n    description: From catalog\n"))
+	assert.NoError(t, err)
+	c.Remove()
+
+	assert.Len(t, muts, 2)
+	assert.Equal(t, codes.MutationRegister, muts[0].Kind)
+	assert.Equal(t, codes.StatusCode(742), muts[0].Code)
+	assert.Equal(t, codes.MutationDelete, muts[1].Kind)
+	assert.Equal(t, "hooks_test.go", filepath.Base(muts[0].Caller.File))
+}
+
+func TestAuditLog(t *testing.T) {
+	codes.EnableAuditLog(2)
+	defer codes.DisableAuditLog()
+
+	codes.RegisterStatusCode(751, "One")
+	codes.RegisterStatusCode(752, "Two")
+	codes.DeleteStatusCode(751)
+	codes.DeleteStatusCode(752)
+
+	// Only the most recent mutations are kept
+	log := codes.AuditLog()
+	assert.Len(t, log, 2)
+	assert.Equal(t, codes.MutationDelete, log[0].Kind)
+	assert.Equal(t, codes.StatusCode(751), log[0].Code)
+	assert.Equal(t, codes.StatusCode(752), log[1].Code)
+
+	codes.EnableAuditLog(1)
+	assert.Equal(t, codes.StatusCode(752), codes.AuditLog()[0].Code)
+
+	codes.DisableAuditLog()
+	codes.RegisterStatusCode(753, "Three")
+	codes.DeleteStatusCode(753)
+	assert.Empty(t, codes.AuditLog())
+
+	// A size of zero or less disables the log
+	codes.EnableAuditLog(4)
+	codes.RegisterStatusCode(753, "Three")
+	codes.EnableAuditLog(-1)
+	assert.Empty(t, codes.AuditLog())
+	codes.DeleteStatusCode(753)
+	assert.Empty(t, codes.AuditLog())
+
+	assert.Equal(t, "register", codes.MutationRegister.String())
+	assert.Equal(t, "delete", codes.MutationDelete.String())
+}
+
+func TestAuditLogOrder(t *testing.T) {
+	codes.EnableAuditLog(100)
+	defer codes.DisableAuditLog()
+	defer codes.DeleteStatusCode(761)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			codes.RegisterStatusCode(761, codes.Description(fmt.Sprint(i)))
+		}(i)
+	}
+	wg.Wait()
+
+	// Every entry follows the previous one, as the registry saw them
+	log := codes.AuditLog()
+	assert.Len(t, log, 20)
+	for i := 1; i < len(log); i++ {
+		assert.Equal(t, log[i-1].New, log[i].Old)
+	}
+	assert.Equal(t, string(log[len(log)-1].New), codes.GetStatusInfo(761))
+}
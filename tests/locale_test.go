@@ -0,0 +1,264 @@
+package code_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+const ptBundle = `language: pt-BR
+status_codes:
+  404:
+    phrase: Não Encontrado
+    description: O recurso solicitado não foi encontrado
+  500:
+    phrase: Erro Interno do Servidor
+`
+
+const ptPTBundle = `{
+  "language": "pt-PT",
+  "status_codes": {
+    "500": {"description": "O servidor encontrou uma condição inesperada"}
+  }
+}`
+
+const deBundle = `language: de
+status_codes:
+  404:
+    phrase: Nicht Gefunden
+    description: Die angeforderte Ressource wurde nicht gefunden
+`
+
+// loadBundles registers the test bundles once.
+func loadBundles(t *testing.T) {
+	t.Helper()
+	for _, in := range []string{ptBundle, ptPTBundle, deBundle} {
+		_, err := codes.LoadBundle(strings.NewReader(in))
+		assert.NoError(t, err)
+	}
+}
+
+func TestStatusLocale(t *testing.T) {
+	loadBundles(t)
+
+	ptBR := language.BrazilianPortuguese
+	ptPT := language.EuropeanPortuguese
+
+	tests := []struct {
+		name   string
+		code   codes.StatusCode
+		tag    language.Tag
+		phrase string
+		desc   string
+	}{
+		{"exact", codes.NotFound, ptBR, "Não Encontrado", "O recurso solicitado não foi encontrado"},
+		{"phrase only", codes.InternalServerError, ptBR, "Erro Interno do Servidor", "Server encountered unexpected condition"},
+		{"description only", codes.InternalServerError, ptPT, "Internal Server Error", "O servidor encontrou uma condição inesperada"},
+		{"parent", codes.NotFound, language.MustParse("de-AT"), "Nicht Gefunden", "Die angeforderte Ressource wurde nicht gefunden"},
+		{"default", codes.NotFound, language.Japanese, "Not Found", "Requested resource could not be found"},
+		{"untranslated", codes.BadRequest, ptBR, "Bad Request", "Server cannot process request due to client error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.phrase, codes.GetStatusPhraseLocale(tt.code, tt.tag))
+			assert.Equal(t, tt.desc, codes.GetStatusInfoLocale(tt.code, tt.tag))
+		})
+	}
+}
+
+func TestLocaleFallback(t *testing.T) {
+	loadBundles(t)
+	ptPT := language.EuropeanPortuguese
+	ptBR := language.BrazilianPortuguese
+
+	assert.Equal(t, []language.Tag{ptPT, language.Portuguese, language.English}, codes.LocaleChain(ptPT))
+	assert.Equal(t, "Internal Server Error", codes.GetStatusPhraseLocale(codes.InternalServerError, ptPT))
+
+	// European Portuguese falls back to Brazilian Portuguese
+	assert.NoError(t, codes.SetLocaleFallback(ptPT, ptBR))
+	defer codes.SetLocaleFallback(ptPT)
+
+	assert.Equal(t, []language.Tag{ptPT, ptBR, language.Portuguese, language.English}, codes.LocaleChain(ptPT))
+	assert.Equal(t, "Erro Interno do Servidor", codes.GetStatusPhraseLocale(codes.InternalServerError, ptPT))
+	assert.Equal(t, "O servidor encontrou uma condição inesperada", codes.GetStatusInfoLocale(codes.InternalServerError, ptPT))
+}
+
+func TestMatchLocale(t *testing.T) {
+	loadBundles(t)
+
+	assert.Contains(t, codes.SupportedLocales(), language.BrazilianPortuguese)
+	assert.Equal(t, language.English, codes.SupportedLocales()[0])
+
+	tests := []struct {
+		header string
+		want   language.Tag
+	}{
+		{"pt-BR,pt;q=0.9,en;q=0.8", language.BrazilianPortuguese},
+		{"de-CH, en;q=0.5", language.German},
+		{"ja", language.English},
+		{"", language.English},
+		{"not a header;q=x", language.English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, codes.MatchLocale(tt.header))
+		})
+	}
+}
+
+func TestLocalizedError(t *testing.T) {
+	loadBundles(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	r.Header.Set("Accept-Language", "pt-BR")
+	w := httptest.NewRecorder()
+	codes.LocalizedError(w, r, codes.NotFound)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "pt-BR", w.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+	assert.Equal(t, "404 Não Encontrado\nO recurso solicitado não foi encontrado\n", w.Body.String())
+
+	// Untranslated codes get the message of the default locale
+	w = httptest.NewRecorder()
+	codes.LocalizedError(w, r, codes.BadRequest)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+	assert.Equal(t, "400 Bad Request\nWe couldn't understand your request. Please check it and try again.\n", w.Body.String())
+
+	// The phrase follows the language of the message, not of the request
+	w = httptest.NewRecorder()
+	codes.LocalizedError(w, r, codes.InternalServerError)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "500 Internal Server Error\n"), w.Body.String())
+}
+
+func TestBuiltinBundles(t *testing.T) {
+	bundles, err := codes.BuiltinBundles()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		lang     language.Tag
+		notFound string
+		internal string
+	}{
+		{language.German, "Nicht gefunden", "Interner Serverfehler"},
+		{language.Spanish, "No Encontrado", "Error Interno del Servidor"},
+		{language.Japanese, "見つかりません", "サーバー内部エラー"},
+		{language.Portuguese, "Não Encontrado", "Erro Interno do Servidor"},
+	}
+	if !assert.Len(t, bundles, len(tests)) {
+		return
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.lang.String(), func(t *testing.T) {
+			b := bundles[i]
+			assert.Equal(t, tt.lang, b.Language)
+			assert.Equal(t, tt.notFound, b.Phrases[codes.NotFound])
+			assert.Equal(t, tt.internal, b.Phrases[codes.InternalServerError])
+
+			// Every built-in code is translated
+			for code := range codes.StatusPhraseMap {
+				if codes.IsValidStatusCode(code) {
+					assert.NotEmpty(t, b.Phrases[code], "phrase of %d", code)
+					assert.NotEmpty(t, b.Descriptions[code], "description of %d", code)
+				}
+			}
+		})
+	}
+}
+
+// Bundles cannot be unregistered, so the built-in bundles are registered in
+// a child process running only TestRegisterBuiltinBundlesChild.
+func TestRegisterBuiltinBundles(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRegisterBuiltinBundlesChild$", "-test.v")
+	cmd.Env = append(os.Environ(), "CODES_BUNDLES_CHILD=1")
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(out))
+	assert.Contains(t, string(out), "--- PASS: TestRegisterBuiltinBundlesChild")
+}
+
+func TestRegisterBuiltinBundlesChild(t *testing.T) {
+	if os.Getenv("CODES_BUNDLES_CHILD") != "1" {
+		t.Skip("run by TestRegisterBuiltinBundles")
+	}
+
+	assert.NoError(t, codes.RegisterBuiltinBundles())
+
+	tests := []struct {
+		header string
+		phrase string
+		desc   string
+	}{
+		{"pt-BR", "Não Encontrado", "O recurso solicitado não foi encontrado"},
+		{"es-MX", "No Encontrado", "No se encontró el recurso solicitado"},
+		{"de-DE", "Nicht gefunden", "Die angeforderte Ressource wurde nicht gefunden"},
+		{"ja", "見つかりません", "要求されたリソースが見つかりませんでした"},
+		{"fr", "Not Found", "Requested resource could not be found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			tag := codes.MatchLocale(tt.header)
+			assert.Equal(t, tt.phrase, codes.GetStatusPhraseLocale(codes.NotFound, tag))
+			assert.Equal(t, tt.desc, codes.GetStatusInfoLocale(codes.NotFound, tag))
+		})
+	}
+}
+
+func TestParseBundleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		line int
+		msg  string
+	}{
+		{"no language", "status_codes: {}\n", 1, "language is required"},
+		{"bad language", "language: 12-xx-!!\n", 1, `invalid language tag "12-xx-!!"`},
+		{"unknown field", "language: es\nversion: 1\n", 2, `unknown field "version"`},
+		{"bad code", "language: es\nstatus_codes:\n  abc:\n    phrase: x\n", 3, `invalid status code "abc"`},
+		{"unknown entry field", "language: es\nstatus_codes:\n  404:\n    title: x\n", 4, `unknown field "title"`},
+		{"empty phrase", "language: es\nstatus_codes:\n  404:\n    phrase: \"\"\n", 4, "empty phrase for status code 404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codes.ParseBundle(strings.NewReader(tt.in))
+			assert.ErrorIs(t, err, codes.ErrInvalidBundle)
+
+			var cerr *codes.CatalogError
+			if assert.True(t, errors.As(err, &cerr)) {
+				assert.Equal(t, tt.line, cerr.Line)
+				assert.Contains(t, cerr.Error(), tt.msg)
+			}
+		})
+	}
+}
+
+func TestLoadBundleFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "es.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("language: es\nstatus_codes:\n  404:\n    phrase: No Encontrado\n"), 0o644))
+
+	b, err := codes.LoadBundleFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, language.Spanish, b.Language)
+	assert.Equal(t, "No Encontrado", codes.GetStatusPhraseLocale(codes.NotFound, language.MustParse("es-MX")))
+
+	// Catalog phrases share the same tables
+	c, err := codes.LoadCatalog(strings.NewReader("status_codes:\n  - code: 771\n    description: Custom\n    phrases:\n      es: Personalizado\n"))
+	assert.NoError(t, err)
+	defer c.Remove()
+	assert.Equal(t, "Personalizado", codes.GetStatusPhraseLocale(771, language.Spanish))
+
+	_, err = codes.LoadCatalog(strings.NewReader("status_codes:\n  - code: 772\n    description: Custom\n    phrases:\n      \"!!\": x\n"))
+	assert.ErrorContains(t, err, `catalog:5: invalid catalog: invalid language tag "!!"`)
+}
@@ -0,0 +1,165 @@
+package code_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseStatusCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected codes.StatusCode
+		errIs    error
+	}{
+		{"Number", "404", codes.NotFound, nil},
+		{"Phrase", "Not Found", codes.NotFound, nil},
+		{"Phrase Case Insensitive", "internal server error", codes.InternalServerError, nil},
+		{"Spaces", " 200 ", codes.OK, nil},
+		{"Unknown Number", "299", 0, codes.ErrUnknownStatusCode},
+		{"Unknown Phrase", "Not A Phrase", 0, codes.ErrUnknownStatusCode},
+		{"Empty", "", 0, codes.ErrUnknownStatusCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := codes.ParseStatusCode(tt.input)
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+}
+
+func TestParseMethod(t *testing.T) {
+	method, err := codes.ParseMethod("PATCH")
+	assert.NoError(t, err)
+	assert.Equal(t, codes.PATCH, method)
+
+	_, err = codes.ParseMethod("patch")
+	assert.ErrorIs(t, err, codes.ErrUnknownMethod)
+
+	_, err = codes.ParseMethod("INVALID")
+	assert.ErrorIs(t, err, codes.ErrUnknownMethod)
+}
+
+func TestGetStatusPhrase(t *testing.T) {
+	assert.Equal(t, "Not Found", codes.GetStatusPhrase(codes.NotFound))
+	assert.Equal(t, "Early Hints", codes.GetStatusPhrase(codes.EarlyHints))
+	assert.Equal(t, "Unknown Status Code", codes.GetStatusPhrase(codes.StatusCode(999)))
+
+	// Every built-in code has a phrase
+	for code := range codes.StatusPhraseMap {
+		_, ok := codes.StatusDescriptionMap[code]
+		assert.True(t, ok, "phrase without description: %d", code)
+	}
+}
+
+func TestStatusCodeJSON(t *testing.T) {
+	type config struct {
+		Retry []codes.StatusCode `json:"retry"`
+	}
+
+	// Encode as bare numbers
+	data, err := json.Marshal(config{Retry: []codes.StatusCode{codes.TooManyRequests, codes.ServiceUnavailable}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"retry":[429,503]}`, string(data))
+
+	// Decode numbers, numeric strings and phrases
+	var cfg config
+	err = json.Unmarshal([]byte(`{"retry":[429,"503","Gateway Timeout"]}`), &cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []codes.StatusCode{codes.TooManyRequests, codes.ServiceUnavailable, codes.GatewayTimeout}, cfg.Retry)
+
+	// Invalid values
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"retry":[999]}`), &cfg), codes.ErrUnknownStatusCode)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"retry":["Nope"]}`), &cfg), codes.ErrUnknownStatusCode)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"retry":[true]}`), &cfg), codes.ErrUnknownStatusCode)
+
+	// Null leaves the value untouched
+	code := codes.OK
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &code))
+	assert.Equal(t, codes.OK, code)
+}
+
+func TestStatusCodeText(t *testing.T) {
+	text, err := codes.NotFound.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "404", string(text))
+
+	// Map keys use the text form
+	data, err := json.Marshal(map[codes.StatusCode]string{codes.OK: "ok"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"200":"ok"}`, string(data))
+
+	var code codes.StatusCode
+	assert.NoError(t, code.UnmarshalText([]byte("Created")))
+	assert.Equal(t, codes.Created, code)
+	assert.ErrorIs(t, code.UnmarshalText([]byte("1000")), codes.ErrUnknownStatusCode)
+}
+
+func TestMethodJSON(t *testing.T) {
+	type config struct {
+		Allowed []codes.Method `json:"allowed"`
+	}
+
+	data, err := json.Marshal(config{Allowed: []codes.Method{codes.GET, codes.POST}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"allowed":["GET","POST"]}`, string(data))
+
+	var cfg config
+	assert.NoError(t, json.Unmarshal([]byte(`{"allowed":["GET","DELETE"]}`), &cfg))
+	assert.Equal(t, []codes.Method{codes.GET, codes.DELETE}, cfg.Allowed)
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"allowed":["FETCH"]}`), &cfg), codes.ErrUnknownMethod)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"allowed":[1]}`), &cfg), codes.ErrUnknownMethod)
+}
+
+func TestDescriptionJSON(t *testing.T) {
+	data, err := json.Marshal(codes.OKDesc)
+	assert.NoError(t, err)
+	assert.Equal(t, `"`+string(codes.OKDesc)+`"`, string(data))
+
+	var desc codes.Description
+	assert.NoError(t, json.Unmarshal([]byte(`"My description"`), &desc))
+	assert.Equal(t, codes.Description("My description"), desc)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"  "`), &desc), codes.ErrEmptyDescription)
+}
+
+func TestYAML(t *testing.T) {
+	type config struct {
+		Retry   []codes.StatusCode `yaml:"retry"`
+		Allowed []codes.Method     `yaml:"allowed"`
+		Desc    codes.Description  `yaml:"desc"`
+	}
+
+	in := config{
+		Retry:   []codes.StatusCode{codes.TooManyRequests},
+		Allowed: []codes.Method{codes.GET},
+		Desc:    codes.Description("Custom"),
+	}
+	data, err := yaml.Marshal(in)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "- 429")
+	assert.Contains(t, string(data), "- GET")
+
+	var out config
+	assert.NoError(t, yaml.Unmarshal(data, &out))
+	assert.Equal(t, in, out)
+
+	// Phrases are accepted
+	assert.NoError(t, yaml.Unmarshal([]byte("retry: [Service Unavailable, \"502\"]"), &out))
+	assert.Equal(t, []codes.StatusCode{codes.ServiceUnavailable, codes.BadGateway}, out.Retry)
+
+	// Invalid values
+	assert.ErrorIs(t, yaml.Unmarshal([]byte("retry: [999]"), &out), codes.ErrUnknownStatusCode)
+	assert.ErrorIs(t, yaml.Unmarshal([]byte("allowed: [FETCH]"), &out), codes.ErrUnknownMethod)
+	assert.ErrorIs(t, yaml.Unmarshal([]byte("desc: \"\""), &out), codes.ErrEmptyDescription)
+}
@@ -0,0 +1,97 @@
+package code_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestMessageDefaults(t *testing.T) {
+	tests := []struct {
+		code codes.StatusCode
+		want string
+	}{
+		{codes.NotFound, "We couldn't find the page you were looking for."},
+		{codes.ServiceUnavailable, "We're temporarily unavailable. Please try again in a few minutes."},
+		// Class messages
+		{codes.Teapot, "There was a problem with your request."},
+		{codes.LoopDetected, "Something went wrong on our side. Please try again later."},
+		{codes.OK, "Your request was completed successfully."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			msg, err := codes.Message(codes.MessageData{Code: tt.code})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, msg)
+		})
+	}
+
+	// Codes without class or template use the description
+	codes.RegisterStatusCode(781, "Custom description")
+	defer codes.DeleteStatusCode(781)
+	msg, err := codes.Message(codes.MessageData{Code: 781})
+	assert.NoError(t, err)
+	assert.Equal(t, "Custom description", msg)
+}
+
+func TestMessageTemplates(t *testing.T) {
+	defer codes.ResetMessages()
+
+	assert.NoError(t, codes.RegisterMessage(codes.NotFound, "We couldn't find {{.Path}}. Reference: {{.RequestID}}"))
+	assert.NoError(t, codes.RegisterMessage(codes.NotFound, "Não encontramos {{.Path}}.", language.BrazilianPortuguese))
+	assert.NoError(t, codes.RegisterClassMessage(codes.ServerError, "{{.Phrase}}: contact {{.Values.support}}"))
+
+	r := httptest.NewRequest(http.MethodGet, "/orders/42?x=1", nil)
+	r.Header.Set("X-Request-Id", "req-1")
+
+	data := codes.NewMessageData(r, codes.NotFound)
+	assert.Equal(t, "GET", data.Method)
+	assert.Equal(t, "/orders/42", data.Path)
+	assert.Equal(t, "x=1", data.Query)
+	assert.Equal(t, "Not Found", data.Phrase)
+	assert.Equal(t, codes.ClientError, data.Class)
+	assert.Equal(t, "We couldn't find /orders/42. Reference: req-1", codes.RequestMessage(r, codes.NotFound))
+
+	// Localized template
+	r.Header.Set("Accept-Language", "pt-BR")
+	loadBundles(t)
+	assert.Equal(t, "Não encontramos /orders/42.", codes.RequestMessage(r, codes.NotFound))
+
+	// Code templates win over class templates
+	msg, err := codes.Message(codes.MessageData{Code: codes.BadGateway})
+	assert.NoError(t, err)
+	assert.Equal(t, "We're having trouble reaching one of our services. Please try again shortly.", msg)
+
+	// Class template with application values
+	msg, err = codes.Message(codes.MessageData{Code: codes.LoopDetected, Values: map[string]string{"support": "help@example.com"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Loop Detected: contact help@example.com", msg)
+
+	// Removing a code template falls back to the class template
+	assert.NoError(t, codes.RegisterMessage(codes.BadGateway, ""))
+	msg, _ = codes.Message(codes.MessageData{Code: codes.BadGateway})
+	assert.Equal(t, "Bad Gateway: contact ", msg)
+
+	// Reset restores the built-in messages
+	codes.ResetMessages()
+	msg, _ = codes.Message(codes.MessageData{Code: codes.BadGateway})
+	assert.Equal(t, "We're having trouble reaching one of our services. Please try again shortly.", msg)
+}
+
+func TestMessageErrors(t *testing.T) {
+	defer codes.ResetMessages()
+
+	assert.ErrorIs(t, codes.RegisterMessage(codes.NotFound, "{{.Path"), codes.ErrInvalidMessage)
+	assert.ErrorIs(t, codes.RegisterClassMessage(codes.UnknownClass, "x"), codes.ErrUnknownClass)
+
+	// Execution errors fall back to the description
+	assert.NoError(t, codes.RegisterMessage(codes.NotFound, "{{.Missing.Field}}"))
+	_, err := codes.Message(codes.MessageData{Code: codes.NotFound})
+	assert.ErrorIs(t, err, codes.ErrInvalidMessage)
+	assert.Equal(t, "Requested resource could not be found", codes.RequestMessage(nil, codes.NotFound))
+}
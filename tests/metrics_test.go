@@ -0,0 +1,236 @@
+package code_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/codes/codestest"
+	"github.com/JuniorVieira99/jr_httpcodes/codes/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsPrometheus(t *testing.T) {
+	c, err := metrics.NewCollector(metrics.Options{Namespace: "api", Buckets: []float64{0.1, 1}})
+	assert.NoError(t, err)
+
+	c.Observe(codes.GET, "/orders", codes.OK, 50*time.Millisecond)
+	c.Observe(codes.GET, "/orders", codes.OK, 500*time.Millisecond)
+	c.Observe(codes.GET, "/orders", codes.NotFound, 2*time.Second)
+	c.Observe("BREW", `/pot"1`, codes.Teapot, 0)
+
+	var b bytes.Buffer
+	assert.NoError(t, c.WritePrometheus(&b))
+	assert.Equal(t, `# HELP api_responses_total Responses by method, route, status code and class.
+# TYPE api_responses_total counter
+api_responses_total{method="GET",route="/orders",code="200",class="2xx"} 2
+api_responses_total{method="GET",route="/orders",code="404",class="4xx"} 1
+api_responses_total{method="OTHER",route="/pot\"1",code="418",class="4xx"} 1
+# HELP api_request_duration_seconds Response latency by method, route and class.
+# TYPE api_request_duration_seconds histogram
+api_request_duration_seconds_bucket{method="GET",route="/orders",class="2xx",le="0.1"} 1
+api_request_duration_seconds_bucket{method="GET",route="/orders",class="2xx",le="1"} 2
+api_request_duration_seconds_bucket{method="GET",route="/orders",class="2xx",le="+Inf"} 2
+api_request_duration_seconds_sum{method="GET",route="/orders",class="2xx"} 0.55
+api_request_duration_seconds_count{method="GET",route="/orders",class="2xx"} 2
+api_request_duration_seconds_bucket{method="GET",route="/orders",class="4xx",le="0.1"} 0
+api_request_duration_seconds_bucket{method="GET",route="/orders",class="4xx",le="1"} 0
+api_request_duration_seconds_bucket{method="GET",route="/orders",class="4xx",le="+Inf"} 1
+api_request_duration_seconds_sum{method="GET",route="/orders",class="4xx"} 2
+api_request_duration_seconds_count{method="GET",route="/orders",class="4xx"} 1
+api_request_duration_seconds_bucket{method="OTHER",route="/pot\"1",class="4xx",le="0.1"} 1
+api_request_duration_seconds_bucket{method="OTHER",route="/pot\"1",class="4xx",le="1"} 1
+api_request_duration_seconds_bucket{method="OTHER",route="/pot\"1",class="4xx",le="+Inf"} 1
+api_request_duration_seconds_sum{method="OTHER",route="/pot\"1",class="4xx"} 0
+api_request_duration_seconds_count{method="OTHER",route="/pot\"1",class="4xx"} 1
+`, b.String())
+
+	assert.Equal(t, uint64(2), c.Count(codes.GET, "/orders", codes.OK))
+	assert.Equal(t, uint64(2), c.ClassCount(codes.ClientError))
+
+	// Unregistered methods count the OTHER responses
+	assert.Equal(t, uint64(1), c.Count("BREW", `/pot"1`, codes.Teapot))
+	assert.Equal(t, uint64(1), c.Count("brew", `/pot"1`, codes.Teapot))
+
+	c.Reset()
+	assert.Equal(t, uint64(0), c.ClassCount(codes.Success))
+}
+
+func TestMetricsOpenMetrics(t *testing.T) {
+	c, err := metrics.NewCollector(metrics.Options{Buckets: []float64{1}})
+	assert.NoError(t, err)
+	c.Observe(codes.POST, "/orders", codes.Created, time.Second)
+
+	var b bytes.Buffer
+	assert.NoError(t, c.WriteOpenMetrics(&b))
+	assert.Equal(t, `# HELP http_responses Responses by method, route, status code and class.
+# TYPE http_responses counter
+http_responses_total{method="POST",route="/orders",code="201",class="2xx"} 1
+# HELP http_request_duration_seconds Response latency by method, route and class.
+# TYPE http_request_duration_seconds histogram
+# UNIT http_request_duration_seconds seconds
+http_request_duration_seconds_bucket{method="POST",route="/orders",class="2xx",le="1"} 1
+http_request_duration_seconds_bucket{method="POST",route="/orders",class="2xx",le="+Inf"} 1
+http_request_duration_seconds_sum{method="POST",route="/orders",class="2xx"} 1
+http_request_duration_seconds_count{method="POST",route="/orders",class="2xx"} 1
+# EOF
+`, b.String())
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	c, err := metrics.NewCollector(metrics.Options{})
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("/orders/", c.Handle("/orders/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/orders/missing" {
+			codes.DefaultErrorPage.Render(w, r, codes.NotFound, nil)
+			return
+		}
+		w.Write([]byte("order"))
+	})))
+	mux.Handle("/hints", c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		codes.WriteEarlyHints(w, codes.Hint{URL: "/style.css", Rel: "preload"})
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	mux.Handle("/empty", c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+	tests := []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodGet, "/orders/1", 200},
+		{http.MethodGet, "/orders/2", 200},
+		{http.MethodDelete, "/orders/missing", 404},
+		// The recorder keeps the 103 Early Hints status
+		{http.MethodGet, "/hints", 103},
+		{http.MethodGet, "/empty", 200},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, tt.code, rec.Code, tt.path)
+	}
+
+	assert.Equal(t, uint64(2), c.Count(codes.GET, "/orders/{id}", codes.OK))
+	assert.Equal(t, uint64(1), c.Count(codes.DELETE, "/orders/{id}", codes.NotFound))
+	// Middleware without Options.Route records a single route
+	assert.Equal(t, uint64(1), c.Count(codes.GET, "unmatched", codes.NoContent))
+	assert.Equal(t, uint64(0), c.Count(codes.GET, "unmatched", codes.EarlyHints))
+	assert.Equal(t, uint64(1), c.Count(codes.GET, "unmatched", codes.OK))
+	assert.Equal(t, uint64(0), c.Count(codes.GET, "/empty", codes.OK))
+
+	// Handler negotiates the format
+	rec := httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	codestest.AssertStatus(t, rec, codes.OK)
+	assert.Equal(t, metrics.PrometheusContentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `http_responses_total{method="GET",route="/orders/{id}",code="200",class="2xx"} 2`)
+	assert.False(t, strings.HasSuffix(rec.Body.String(), "# EOF\n"))
+
+	r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	r.Header.Set("Accept", "application/openmetrics-text;version=1.0.0,text/plain;q=0.5")
+	rec = httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, r)
+	assert.Equal(t, metrics.OpenMetricsContentType, rec.Header().Get("Content-Type"))
+	assert.True(t, strings.HasSuffix(rec.Body.String(), "# EOF\n"))
+}
+
+func TestMetricsHijack(t *testing.T) {
+	c, err := metrics.NewCollector(metrics.Options{})
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(c.Handle("/ws", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n\r\n")
+		rw.Flush()
+	})))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	conn.Write([]byte("GET / HTTP/1.1\r\nHost: example.com\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n\r\n"))
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	}
+
+	assert.Eventually(t, func() bool {
+		return c.Count(codes.GET, "/ws", codes.SwitchingProtocols) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Only the interfaces of the underlying writer are exposed
+	c.Handle("/ws", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := w.(http.Hijacker)
+		assert.False(t, ok)
+		_, ok = w.(http.Pusher)
+		assert.False(t, ok)
+		_, ok = w.(http.Flusher)
+		assert.True(t, ok)
+		assert.NoError(t, http.NewResponseController(w).Flush())
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ws", nil))
+
+	c.Handle("/plain", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := w.(http.Flusher)
+		assert.False(t, ok)
+		w.WriteHeader(http.StatusAccepted)
+	})).ServeHTTP(plainWriter{httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, "/plain", nil))
+	assert.Equal(t, uint64(1), c.Count(codes.GET, "/plain", codes.Accepted))
+}
+
+// plainWriter hides the optional interfaces of a ResponseWriter.
+type plainWriter struct {
+	http.ResponseWriter
+}
+
+// failingWriter is a ResponseWriter whose writes fail.
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestMetricsHandlerWriteError(t *testing.T) {
+	c, err := metrics.NewCollector(metrics.Options{})
+	assert.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		c.Handler().ServeHTTP(failingWriter{httptest.NewRecorder()}, r)
+	})
+}
+
+func TestMetricsOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts metrics.Options
+	}{
+		{"namespace", metrics.Options{Namespace: "my-api"}},
+		{"decreasing buckets", metrics.Options{Buckets: []float64{1, 0.5}}},
+		{"negative bucket", metrics.Options{Buckets: []float64{-1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := metrics.NewCollector(tt.opts)
+			assert.ErrorIs(t, err, metrics.ErrInvalidOptions)
+		})
+	}
+}
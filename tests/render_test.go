@@ -0,0 +1,203 @@
+package code_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+var renderStatusCodes = map[codes.StatusCode]codes.Description{
+	codes.NotFound:            codes.NotFoundDesc,
+	codes.OK:                  codes.OKDesc,
+	codes.BadRequest:          codes.BadRequestDesc,
+	codes.InternalServerError: codes.InternalServerErrorDesc,
+}
+
+func TestSortedStatusCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    codes.Order
+		expected []codes.StatusCode
+	}{
+		{"By Code", codes.OrderByCode, []codes.StatusCode{200, 400, 404, 500}},
+		{"By Class", codes.OrderByClass, []codes.StatusCode{200, 400, 404, 500}},
+		{"By Name", codes.OrderByName, []codes.StatusCode{400, 500, 404, 200}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, codes.SortedStatusCodes(renderStatusCodes, tt.order))
+		})
+	}
+
+	// Within a class, phrases decide
+	m := map[codes.StatusCode]codes.Description{
+		codes.NotFound:   codes.NotFoundDesc,
+		codes.BadRequest: codes.BadRequestDesc,
+		codes.Conflict:   codes.ConflictDesc,
+		codes.OK:         codes.OKDesc,
+	}
+	assert.Equal(t, []codes.StatusCode{200, 400, 409, 404}, codes.SortedStatusCodes(m, codes.OrderByClass))
+}
+
+func TestSortedMethods(t *testing.T) {
+	m := codes.GET.CallMap()
+	assert.Equal(t,
+		[]codes.Method{"CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT", "TRACE"},
+		codes.SortedMethods(m, codes.OrderByName),
+	)
+	assert.Equal(t,
+		[]codes.Method{"GET", "HEAD", "OPTIONS", "TRACE", "DELETE", "PUT", "CONNECT", "PATCH", "POST"},
+		codes.SortedMethods(m, codes.OrderByClass),
+	)
+}
+
+func TestStringMapsDeterministic(t *testing.T) {
+	first := codes.StringStatusCodeMap(renderStatusCodes)
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, first, codes.StringStatusCodeMap(renderStatusCodes))
+	}
+	assert.True(t, strings.HasPrefix(first, "200 -> "))
+
+	methods := codes.StringMethodMap(codes.MethodDescriptionMap)
+	assert.True(t, strings.HasPrefix(methods, "CONNECT -> "))
+}
+
+func TestRenderText(t *testing.T) {
+	var buf bytes.Buffer
+	opts := &codes.RenderOptions{StatusCodes: map[codes.StatusCode]codes.Description{
+		codes.OK:       codes.OKDesc,
+		codes.NotFound: codes.NotFoundDesc,
+	}}
+	assert.NoError(t, codes.Render(&buf, codes.FormatText, opts))
+
+	expected := "" +
+		"Code  Phrase     Class  Description\n" +
+		"----  ---------  -----  ------------------------------------------------------\n" +
+		"200   OK         2xx    Request succeeded and response contains requested data\n" +
+		"404   Not Found  4xx    Requested resource could not be found\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	opts := &codes.RenderOptions{StatusCodes: map[codes.StatusCode]codes.Description{
+		codes.Teapot: codes.Description("Short | and stout"),
+	}}
+	assert.NoError(t, codes.Render(&buf, codes.FormatMarkdown, opts))
+
+	expected := "" +
+		"| Code | Phrase | Class | Description |\n" +
+		"|------|------|------|------|\n" +
+		"| 418 | I'm a teapot | 4xx | Short \\| and stout |\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, codes.Render(&buf, codes.FormatCSV, &codes.RenderOptions{StatusCodes: renderStatusCodes}))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 5)
+	assert.Equal(t, []string{"Code", "Phrase", "Class", "Description"}, records[0])
+	assert.Equal(t, []string{"500", "Internal Server Error", "5xx", string(codes.InternalServerErrorDesc)}, records[4])
+}
+
+func TestRenderJSON(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, codes.Render(&buf, codes.FormatJSON, &codes.RenderOptions{StatusCodes: renderStatusCodes}))
+
+	var rows []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	assert.Len(t, rows, 4)
+	assert.Equal(t, float64(200), rows[0]["code"])
+	assert.Equal(t, "OK", rows[0]["phrase"])
+	assert.Equal(t, "2xx", rows[0]["class"])
+
+	// Keys keep column order
+	assert.True(t, strings.HasPrefix(buf.String(), "[\n  {\"code\":200,\"phrase\":"))
+
+	// Methods keep native booleans
+	buf.Reset()
+	assert.NoError(t, codes.RenderMethods(&buf, codes.FormatJSON, nil))
+	var methods []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &methods))
+	assert.Equal(t, "CONNECT", methods[0]["method"])
+	assert.Equal(t, false, methods[0]["safe"])
+}
+
+func TestRenderHTML(t *testing.T) {
+	var buf bytes.Buffer
+	opts := &codes.RenderOptions{StatusCodes: map[codes.StatusCode]codes.Description{codes.Teapot: codes.TeapotDesc}}
+	assert.NoError(t, codes.Render(&buf, codes.FormatHTML, opts))
+
+	out := buf.String()
+	assert.Contains(t, out, "<th>Code</th><th>Phrase</th>")
+	assert.Contains(t, out, "<td>418</td><td>I&#39;m a teapot</td>")
+}
+
+func TestRenderDefaultsAndErrors(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, codes.Render(&buf, codes.FormatText, nil))
+	assert.Contains(t, buf.String(), "511   Network Authentication Required")
+
+	assert.ErrorIs(t, codes.Render(&buf, codes.Format(99), nil), codes.ErrUnknownFormat)
+	assert.ErrorIs(t, codes.RenderMethods(&buf, codes.Format(99), nil), codes.ErrUnknownFormat)
+}
+
+func TestRegisterRenderer(t *testing.T) {
+	const formatCount codes.Format = 100
+	codes.RegisterRenderer(formatCount, func(w io.Writer, table *codes.Table) error {
+		_, err := io.WriteString(w, strings.Repeat("x", len(table.Rows)))
+		return err
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, codes.Render(&buf, formatCount, &codes.RenderOptions{StatusCodes: renderStatusCodes}))
+	assert.Equal(t, "xxxx", buf.String())
+}
+
+func TestRegisterRendererConcurrent(t *testing.T) {
+	const formatNop codes.Format = 101
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			codes.RegisterRenderer(formatNop, func(io.Writer, *codes.Table) error { return nil })
+		}()
+		go func() {
+			defer wg.Done()
+			codes.Render(io.Discard, codes.FormatText, &codes.RenderOptions{StatusCodes: renderStatusCodes})
+		}()
+	}
+	wg.Wait()
+
+	assert.NoError(t, codes.Render(io.Discard, formatNop, nil))
+}
+
+func TestStatusCodeTableDoc(t *testing.T) {
+	doc, err := os.ReadFile("../docs/codes_doc.md")
+	assert.NoError(t, err)
+
+	// The table follows the line naming the call that renders it
+	const marker = "Generated with `codes.Render(os.Stdout, codes.FormatMarkdown, nil)`.\n\n"
+	_, section, found := strings.Cut(string(doc), marker)
+	if !assert.True(t, found, "status code table marker not found") {
+		return
+	}
+	section, _, _ = strings.Cut(section, "\n\n")
+
+	var buf bytes.Buffer
+	assert.NoError(t, codes.Render(&buf, codes.FormatMarkdown, nil))
+	assert.Equal(t, strings.TrimSpace(buf.String()), section, "docs/codes_doc.md is out of date, paste the output of codes.Render")
+}
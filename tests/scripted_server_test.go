@@ -0,0 +1,172 @@
+package code_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/codes/codestest"
+	"github.com/stretchr/testify/assert"
+)
+
+// statuses sends n requests and returns their status codes.
+func statuses(t *testing.T, method, url string, n int) []int {
+	t.Helper()
+	var got []int
+	for i := 0; i < n; i++ {
+		req, _ := http.NewRequest(method, url, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if !assert.NoError(t, err) {
+			return got
+		}
+		resp.Body.Close()
+		got = append(got, resp.StatusCode)
+	}
+	return got
+}
+
+func TestScriptedServerSequences(t *testing.T) {
+	srv := codestest.NewScriptedServer(map[string]codestest.Script{
+		"/orders": {Codes: []codes.StatusCode{503, 503, 200}},
+		"/loop":   {Codes: []codes.StatusCode{200, 500}, Loop: true},
+		"":        {Codes: []codes.StatusCode{codes.Teapot}},
+	})
+	defer srv.Close()
+
+	tests := []struct {
+		path string
+		want []int
+	}{
+		{"/orders", []int{503, 503, 200, 200}},
+		{"/loop", []int{200, 500, 200, 500, 200}},
+		{"/other", []int{418, 418}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, statuses(t, http.MethodGet, srv.URL+tt.path, len(tt.want)))
+		})
+	}
+
+	// Reset restarts the sequences
+	srv.Reset()
+	assert.Equal(t, []int{503}, statuses(t, http.MethodGet, srv.URL+"/orders", 1))
+
+	// Routes without script reply 404
+	srv2 := codestest.NewScriptedServer(nil)
+	defer srv2.Close()
+	assert.Equal(t, []int{404}, statuses(t, http.MethodGet, srv2.URL+"/missing", 1))
+	srv2.Script("/missing", codestest.Script{})
+	assert.Equal(t, []int{200}, statuses(t, http.MethodGet, srv2.URL+"/missing", 1))
+}
+
+func TestScriptedServerClasses(t *testing.T) {
+	srv := codestest.NewScriptedServer(map[string]codestest.Script{
+		"/flaky": {
+			Codes:   []codes.StatusCode{codes.InternalServerError},
+			Classes: map[codes.Class]float64{codes.Success: 3, codes.ServerError: 1},
+		},
+	})
+	defer srv.Close()
+
+	got := statuses(t, http.MethodGet, srv.URL+"/flaky", 201)
+	assert.Equal(t, 500, got[0])
+
+	counts := map[int]int{}
+	for _, code := range got[1:] {
+		counts[code]++
+	}
+	assert.Len(t, counts, 2)
+	assert.InDelta(t, 150, counts[200], 25)
+	assert.InDelta(t, 50, counts[503], 25)
+
+	// Draws are deterministic for a seed
+	srv.Seed(7)
+	srv.Reset()
+	first := statuses(t, http.MethodGet, srv.URL+"/flaky", 20)
+	srv.Seed(7)
+	srv.Reset()
+	assert.Equal(t, first, statuses(t, http.MethodGet, srv.URL+"/flaky", 20))
+}
+
+func TestScriptedServerHeadersAndLatency(t *testing.T) {
+	srv := codestest.NewScriptedServer(map[string]codestest.Script{
+		"/limited": {Codes: []codes.StatusCode{429, 200}, RetryAfter: 1500 * time.Millisecond},
+		"/slow":    {Latency: 50 * time.Millisecond},
+	})
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/limited")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	codestest.AssertStatus(t, resp, codes.TooManyRequests)
+
+	resp, err = http.Get(srv.URL + "/limited")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, resp.Header.Get("Retry-After"))
+
+	start := time.Now()
+	assert.Equal(t, []int{200}, statuses(t, http.MethodGet, srv.URL+"/slow", 1))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// Client timeouts are not blocked by the latency
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/slow", nil)
+	_, err = http.DefaultClient.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestScriptedServerRecording(t *testing.T) {
+	srv := codestest.NewScriptedServer(map[string]codestest.Script{
+		"/orders": {Codes: []codes.StatusCode{201, 409}},
+	})
+	defer srv.Close()
+
+	statuses(t, http.MethodPost, srv.URL+"/orders", 2)
+	statuses(t, http.MethodDelete, srv.URL+"/orders", 1)
+	statuses(t, http.MethodGet, srv.URL+"/other", 1)
+
+	assert.Equal(t, []codes.Method{codes.POST, codes.POST, codes.DELETE}, srv.Methods("/orders"))
+	assert.Equal(t, 3, srv.Count("/orders"))
+
+	requests := srv.Requests()
+	assert.Len(t, requests, 4)
+	assert.Equal(t, codes.Conflict, requests[1].Code)
+	assert.Equal(t, codes.DELETE, requests[2].Method)
+	assert.Equal(t, "/other", requests[3].Path)
+	assert.Equal(t, codes.NotFound, requests[3].Code)
+}
+
+func TestScriptedServerInvalidScript(t *testing.T) {
+	tests := []struct {
+		name   string
+		script codestest.Script
+		ok     bool
+	}{
+		{"Final Codes", codestest.Script{Codes: []codes.StatusCode{200, 599}}, true},
+		{"Informational", codestest.Script{Codes: []codes.StatusCode{200, codes.EarlyHints}}, false},
+		{"Zero", codestest.Script{Codes: []codes.StatusCode{0}}, false},
+		{"Custom", codestest.Script{Codes: []codes.StatusCode{701}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.script.Validate()
+			if tt.ok {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, codestest.ErrInvalidScript)
+			assert.Panics(t, func() { codestest.NewScriptedServer(map[string]codestest.Script{"/": tt.script}) })
+
+			srv := codestest.NewScriptedServer(nil)
+			defer srv.Close()
+			assert.Panics(t, func() { srv.Script("/", tt.script) })
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package code_test
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+// Sealing cannot be undone, so the sealed registry is tested in a child
+// process running only TestSealChild.
+func TestSeal(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSealChild$", "-test.v")
+	cmd.Env = append(os.Environ(), "CODES_SEAL_CHILD=1")
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(out))
+	assert.Contains(t, string(out), "--- PASS: TestSealChild")
+	assert.False(t, codes.IsSealed())
+}
+
+// assertSealed checks fn fails with ErrSealed, returned or, in codesdebug
+// builds, panicked.
+func assertSealed(t *testing.T, fn func() error) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			assert.Equal(t, codes.ErrSealed, r)
+		}
+	}()
+	assert.ErrorIs(t, fn(), codes.ErrSealed)
+}
+
+func TestSealChild(t *testing.T) {
+	if os.Getenv("CODES_SEAL_CHILD") != "1" {
+		t.Skip("run by TestSeal")
+	}
+
+	assert.NoError(t, codes.RegisterStatusCode(761, "Before seal"))
+	assert.NoError(t, codes.RegisterMethod("SEALED", "Before seal"))
+	c, err := codes.LoadCatalog(strings.NewReader("status_codes:\n  - code: 762\n    description: From catalog\n"))
+	assert.NoError(t, err)
+
+	assert.False(t, codes.IsSealed())
+	codes.Seal()
+	assert.True(t, codes.IsSealed())
+
+	// Mutations fail
+	assertSealed(t, func() error { return codes.RegisterStatusCode(763, "After seal") })
+	assertSealed(t, func() error { return codes.DeleteStatusCode(761) })
+	assertSealed(t, func() error { return codes.RegisterMethod("LATE", "After seal") })
+	assertSealed(t, func() error { return codes.DeleteMethod("SEALED") })
+	assertSealed(t, c.Remove)
+	assertSealed(t, func() error {
+		_, err := codes.LoadCatalog(strings.NewReader("status_codes:\n  - code: 764\n    description: x\n"))
+		return err
+	})
+
+	// Reads keep working, concurrently and without locks
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info, ok := codes.LookupStatus(761)
+			assert.True(t, ok)
+			assert.Equal(t, codes.Description("Before seal"), info.Description)
+
+			code, err := codes.ParseStatusCode("762")
+			assert.NoError(t, err)
+			assert.Equal(t, codes.StatusCode(762), code)
+
+			_, ok = codes.LookupMethod("SEALED")
+			assert.True(t, ok)
+			_, ok = codes.LookupStatus(763)
+			assert.False(t, ok)
+
+			assert.Equal(t, "Before seal", codes.GetStatusInfo(761))
+			assert.Equal(t, "Not Found", codes.GetStatusPhrase(codes.NotFound))
+			assert.NoError(t, codes.ValidateMethod("SEALED"))
+			assert.True(t, codes.IsSafeMethod(codes.GET))
+			assert.NoError(t, codes.Render(io.Discard, codes.FormatText, nil))
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,93 @@
+package code_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+// matchCodes returns the status codes of the matches, in order.
+func matchCodes(matches []codes.Match) []codes.StatusCode {
+	var out []codes.StatusCode
+	for _, m := range matches {
+		if m.Kind == codes.MatchStatusCode {
+			out = append(out, m.Code)
+		}
+	}
+	return out
+}
+
+func TestSearchNumbers(t *testing.T) {
+	// Exact number first
+	matches := codes.Search("404")
+	assert.Len(t, matches, 1)
+	assert.Equal(t, codes.NotFound, matches[0].Code)
+	assert.Equal(t, float64(100), matches[0].Score)
+
+	// Prefix
+	assert.Equal(t,
+		[]codes.StatusCode{500, 501, 502, 503, 504, 505, 506, 507, 508},
+		matchCodes(codes.Search("50")),
+	)
+
+	// Wildcards
+	assert.Equal(t, []codes.StatusCode{100, 101, 102, 103}, matchCodes(codes.Search("1xx")))
+	assert.Equal(t, []codes.StatusCode{103, 203, 303, 403, 503}, matchCodes(codes.Search("?03")))
+	assert.Empty(t, codes.Search("9xx"))
+}
+
+func TestSearchText(t *testing.T) {
+	// Exact phrase
+	matches := codes.Search("not found")
+	assert.Equal(t, codes.NotFound, matches[0].Code)
+	assert.Equal(t, "Not Found", matches[0].Name)
+
+	// Tokens over descriptions
+	found := matchCodes(codes.Search("timeout"))
+	assert.Equal(t, []codes.StatusCode{codes.RequestTimeout, codes.GatewayTimeout}, found)
+
+	// Prefix tokens
+	found = matchCodes(codes.Search("auth"))
+	assert.Contains(t, found, codes.Unauthorized)
+	assert.Contains(t, found, codes.ProxyAuthRequired)
+	assert.Contains(t, found, codes.NetworkAuthenticationRequired)
+
+	// Every token must match
+	found = matchCodes(codes.Search("too many"))
+	assert.Equal(t, []codes.StatusCode{codes.TooManyRequests}, found)
+	assert.Empty(t, codes.Search("timeout teapot"))
+}
+
+func TestSearchFuzzy(t *testing.T) {
+	exact := codes.Search("timeout")
+	typo := codes.Search("timout")
+
+	assert.Equal(t, matchCodes(exact), matchCodes(typo))
+	assert.Less(t, typo[0].Score, exact[0].Score)
+
+	assert.Contains(t, matchCodes(codes.Search("unavailabel")), codes.ServiceUnavailable)
+
+	// Short tokens are not fuzzy matched
+	assert.Empty(t, codes.Search("zzz"))
+}
+
+func TestSearchMethods(t *testing.T) {
+	matches := codes.Search("PATCH")
+	assert.Equal(t, codes.MatchMethod, matches[0].Kind)
+	assert.Equal(t, codes.PATCH, matches[0].Method)
+	assert.Equal(t, float64(100), matches[0].Score)
+
+	matches = codes.Search("metadata")
+	assert.Equal(t, codes.HEAD, matches[0].Method)
+
+	data, err := json.Marshal(matches[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"kind":"method","method":"HEAD","name":"HEAD","description":"Retrieve metadata from server","score":42}`, string(data))
+}
+
+func TestSearchEmpty(t *testing.T) {
+	assert.Nil(t, codes.Search(""))
+	assert.Nil(t, codes.Search("   "))
+}
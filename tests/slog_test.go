@@ -0,0 +1,146 @@
+package code_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClass(t *testing.T) {
+	tests := []struct {
+		code  codes.StatusCode
+		class codes.Class
+		short string
+		name  string
+	}{
+		{codes.Continue, codes.Informational, "1xx", "Informational"},
+		{codes.OK, codes.Success, "2xx", "Successful"},
+		{codes.Found, codes.Redirection, "3xx", "Redirection"},
+		{codes.NotFound, codes.ClientError, "4xx", "Client Error"},
+		{codes.BadGateway, codes.ServerError, "5xx", "Server Error"},
+		{codes.StatusCode(700), codes.UnknownClass, "unknown", "Unknown Class"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.short, func(t *testing.T) {
+			assert.Equal(t, tt.class, codes.ClassOf(tt.code))
+			assert.Equal(t, tt.class, tt.code.Class())
+			assert.Equal(t, tt.short, tt.class.String())
+			assert.Equal(t, tt.name, tt.class.Name())
+			assert.Equal(t, tt.class != codes.UnknownClass, tt.class.Contains(tt.code))
+		})
+	}
+}
+
+func TestClassStatusCodes(t *testing.T) {
+	assert.Equal(t, []codes.StatusCode{codes.Continue, codes.SwitchingProtocols, codes.Processing, codes.EarlyHints}, codes.Informational.StatusCodes())
+	assert.Contains(t, codes.ClientError.StatusCodes(), codes.NotFound)
+	assert.NotContains(t, codes.ClientError.StatusCodes(), codes.InternalServerError)
+	assert.Empty(t, codes.UnknownClass.StatusCodes())
+}
+
+func TestMethodSemantics(t *testing.T) {
+	tests := []struct {
+		method     codes.Method
+		safe       bool
+		idempotent bool
+	}{
+		{codes.GET, true, true},
+		{codes.HEAD, true, true},
+		{codes.OPTIONS, true, true},
+		{codes.TRACE, true, true},
+		{codes.PUT, false, true},
+		{codes.DELETE, false, true},
+		{codes.POST, false, false},
+		{codes.PATCH, false, false},
+		{codes.CONNECT, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			assert.Equal(t, tt.safe, codes.IsSafeMethod(tt.method))
+			assert.Equal(t, tt.idempotent, codes.IsIdempotentMethod(tt.method))
+		})
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	logger.Info("done", "status", codes.NotFound, "method", codes.PUT)
+
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	status := entry["status"].(map[string]interface{})
+	assert.Equal(t, float64(404), status["code"])
+	assert.Equal(t, "Not Found", status["phrase"])
+	assert.Equal(t, "4xx", status["class"])
+	assert.Equal(t, string(codes.NotFoundDesc), status["description"])
+
+	method := entry["method"].(map[string]interface{})
+	assert.Equal(t, "PUT", method["name"])
+	assert.Equal(t, string(codes.PUTDesc), method["description"])
+	assert.Equal(t, false, method["safe"])
+	assert.Equal(t, true, method["idempotent"])
+}
+
+func TestStatusHandlerLevels(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger := slog.New(codes.NewStatusHandler(base, nil))
+
+	tests := []struct {
+		name     string
+		log      func()
+		expected string
+	}{
+		{"Success Unchanged", func() { logger.Info("ok", "status", codes.OK) }, "level=INFO"},
+		{"Client Error Warn", func() { logger.Info("bad", "status", codes.NotFound) }, "level=WARN"},
+		{"Server Error", func() { logger.Info("boom", "status", codes.BadGateway) }, "level=ERROR"},
+		{"Plain Int Key", func() { logger.Info("boom", "status", 503) }, "level=ERROR"},
+		{"Never Lowered", func() { logger.Error("bad", "status", codes.NotFound) }, "level=ERROR"},
+		{"WithAttrs", func() { logger.With("status", codes.Conflict).Info("bad") }, "level=WARN"},
+		{"Debug Raised", func() { logger.Debug("boom", "status", codes.InternalServerError) }, "level=ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.log()
+			assert.Contains(t, buf.String(), tt.expected)
+		})
+	}
+
+	// Debug records without a raising status stay filtered
+	buf.Reset()
+	logger.Debug("quiet", "status", codes.OK)
+	assert.Empty(t, buf.String())
+}
+
+func TestStatusHandlerOptions(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.NewTextHandler(&buf, nil)
+	handler := codes.NewStatusHandler(base, &codes.StatusHandlerOptions{
+		Levels:    map[codes.Class]slog.Level{codes.ClientError: slog.LevelError},
+		Color:     true,
+		StatusKey: "code",
+	})
+	logger := slog.New(handler).WithGroup("http")
+
+	logger.Info("bad", "code", 404)
+	out := buf.String()
+	assert.Contains(t, out, "level=ERROR")
+	// The text handler quotes the ANSI escapes
+	assert.Contains(t, out, `msg="\x1b[33mbad\x1b[0m"`)
+
+	// 5xx not configured, level untouched
+	buf.Reset()
+	logger.Info("boom", "code", 500)
+	assert.Contains(t, buf.String(), "level=INFO")
+}
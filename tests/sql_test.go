@@ -0,0 +1,197 @@
+package code_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+// Fake Driver
+// --------------------------------------------------------------------
+
+// fakeDriver is an in-memory database/sql driver holding a single table per DSN.
+// Every Exec appends its arguments as a row, every Query returns all rows.
+type fakeDriver struct {
+	mu     sync.Mutex
+	tables map[string][][]driver.Value
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+	name   string
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+	pos  int
+}
+
+var testDriver = &fakeDriver{tables: map[string][][]driver.Value{}}
+
+func init() {
+	sql.Register("codesfake", testDriver)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{driver: d, name: name}, nil
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+func (s *fakeStmt) Close() error { return nil }
+
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	s.conn.driver.tables[s.conn.name] = append(s.conn.driver.tables[s.conn.name], args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	return &fakeRows{rows: s.conn.driver.tables[s.conn.name]}, nil
+}
+
+func (r *fakeRows) Columns() []string { return []string{"status", "method"} }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+// Tests
+// --------------------------------------------------------------------
+
+func TestSQLRoundTrip(t *testing.T) {
+	db, err := sql.Open("codesfake", t.Name())
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("INSERT", codes.NotFound, codes.GET)
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT", codes.Created, codes.POST)
+	assert.NoError(t, err)
+
+	rows, err := db.Query("SELECT")
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var (
+		statuses []codes.StatusCode
+		methods  []codes.Method
+	)
+	for rows.Next() {
+		var (
+			status codes.StatusCode
+			method codes.Method
+		)
+		assert.NoError(t, rows.Scan(&status, &method))
+		statuses = append(statuses, status)
+		methods = append(methods, method)
+	}
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, []codes.StatusCode{codes.NotFound, codes.Created}, statuses)
+	assert.Equal(t, []codes.Method{codes.GET, codes.POST}, methods)
+}
+
+func TestSQLValueValidation(t *testing.T) {
+	db, err := sql.Open("codesfake", t.Name())
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("INSERT", codes.StatusCode(999), codes.GET)
+	assert.ErrorIs(t, err, codes.ErrUnknownStatusCode)
+
+	_, err = db.Exec("INSERT", codes.OK, codes.Method("FETCH"))
+	assert.ErrorIs(t, err, codes.ErrUnknownMethod)
+}
+
+func TestSQLScanValidation(t *testing.T) {
+	db, err := sql.Open("codesfake", t.Name())
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Bypass the Valuer with raw values, as a foreign writer would.
+	testDriver.mu.Lock()
+	testDriver.tables[t.Name()] = [][]driver.Value{
+		{int64(999), "GET"},
+		{"Not Found", []byte("FETCH")},
+		{nil, nil},
+		{[]byte("503"), "PUT"},
+	}
+	testDriver.mu.Unlock()
+
+	rows, err := db.Query("SELECT")
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	expected := []struct {
+		statusErr error
+		methodErr error
+	}{
+		{codes.ErrUnknownStatusCode, nil},
+		{nil, codes.ErrUnknownMethod},
+		{codes.ErrUnknownStatusCode, codes.ErrUnknownMethod},
+		{nil, nil},
+	}
+
+	i := 0
+	for rows.Next() {
+		var (
+			status codes.StatusCode
+			method codes.Method
+		)
+		// Scan each column on its own to check both errors.
+		assert.ErrorIs(t, status.Scan(testDriver.tables[t.Name()][i][0]), expected[i].statusErr)
+		assert.ErrorIs(t, method.Scan(testDriver.tables[t.Name()][i][1]), expected[i].methodErr)
+
+		err := rows.Scan(&status, &method)
+		if expected[i].statusErr != nil {
+			assert.ErrorIs(t, err, expected[i].statusErr)
+		} else if expected[i].methodErr != nil {
+			assert.ErrorIs(t, err, expected[i].methodErr)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, codes.ServiceUnavailable, status)
+			assert.Equal(t, codes.PUT, method)
+		}
+		i++
+	}
+	assert.Equal(t, 4, i)
+}
+
+func TestSQLScanUnsupportedType(t *testing.T) {
+	var status codes.StatusCode
+	assert.ErrorIs(t, status.Scan(3.14), codes.ErrUnknownStatusCode)
+
+	var method codes.Method
+	assert.ErrorIs(t, method.Scan(int64(1)), codes.ErrUnknownMethod)
+}
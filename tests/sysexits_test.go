@@ -0,0 +1,143 @@
+package code_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/JuniorVieira99/jr_httpcodes/codes/sysexits"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSysexitsToExit(t *testing.T) {
+	tests := []struct {
+		status codes.StatusCode
+		want   sysexits.Code
+	}{
+		{codes.OK, sysexits.OK},
+		{codes.NoContent, sysexits.OK},
+		{codes.BadRequest, sysexits.DataErr},
+		{codes.Unauthorized, sysexits.NoPerm},
+		{codes.Forbidden, sysexits.NoPerm},
+		{codes.NotFound, sysexits.NoInput},
+		{codes.TooManyRequests, sysexits.TempFail},
+		{codes.InternalServerError, sysexits.Software},
+		{codes.ServiceUnavailable, sysexits.Unavailable},
+		{codes.GatewayTimeout, sysexits.TempFail},
+		// Class fallbacks
+		{codes.Teapot, sysexits.DataErr},
+		{codes.LoopDetected, sysexits.Unavailable},
+		{codes.MovedPermanently, sysexits.Protocol},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, sysexits.ToExit(tt.status))
+		})
+	}
+}
+
+func TestSysexitsFromExit(t *testing.T) {
+	tests := []struct {
+		code sysexits.Code
+		want codes.StatusCode
+	}{
+		{sysexits.OK, codes.OK},
+		{sysexits.Usage, codes.BadRequest},
+		{sysexits.DataErr, codes.BadRequest},
+		{sysexits.NoInput, codes.NotFound},
+		{sysexits.NoPerm, codes.Forbidden},
+		{sysexits.Unavailable, codes.ServiceUnavailable},
+		{sysexits.TempFail, codes.ServiceUnavailable},
+		{sysexits.Software, codes.InternalServerError},
+		{sysexits.Code(1), codes.InternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			status := sysexits.FromExit(tt.code)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
+
+func TestSysexitsOverrides(t *testing.T) {
+	m := sysexits.NewMapper()
+
+	assert.NoError(t, m.Override(codes.Conflict, sysexits.CantCreat))
+	assert.Equal(t, sysexits.CantCreat, m.ToExit(codes.Conflict))
+	assert.Equal(t, codes.Conflict, m.FromExit(sysexits.CantCreat))
+
+	assert.NoError(t, m.SetToExit(codes.NotFound, sysexits.Usage))
+	assert.Equal(t, sysexits.Usage, m.ToExit(codes.NotFound))
+	assert.Equal(t, codes.BadRequest, m.FromExit(sysexits.Usage))
+
+	assert.ErrorIs(t, m.SetFromExit(1, codes.OK), sysexits.ErrInvalidCode)
+	assert.ErrorIs(t, m.SetToExit(42, sysexits.OK), sysexits.ErrInvalidStatus)
+
+	// Overrides of a Mapper do not change Default
+	assert.Equal(t, sysexits.NoInput, sysexits.ToExit(codes.NotFound))
+
+	m.Reset()
+	assert.Equal(t, sysexits.NoInput, m.ToExit(codes.NotFound))
+}
+
+func TestSysexitsFromError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code sysexits.Code
+		msg  string
+	}{
+		{"nil", nil, sysexits.OK, ""},
+		{"http error", codes.NewHTTPError(codes.Forbidden, "Token expired", nil), sysexits.NoPerm, "403 Forbidden: Token expired"},
+		{"wrapped", fmt.Errorf("listing orders: %w", codes.NewHTTPError(codes.ServiceUnavailable, "", nil)), sysexits.Unavailable, "listing orders: 503 Service Unavailable"},
+		{"deadline", context.DeadlineExceeded, sysexits.TempFail, "context deadline exceeded"},
+		{"other", errors.New("boom"), sysexits.Software, "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, msg := sysexits.FromError(tt.err)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.msg, msg)
+		})
+	}
+
+	var b bytes.Buffer
+	assert.Equal(t, sysexits.NoPerm, sysexits.Fprint(&b, codes.NewHTTPError(codes.Unauthorized, "", nil)))
+	assert.Regexp(t, `^\S+: 401 Unauthorized\n$`, b.String())
+}
+
+func TestSysexitsParseCode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  sysexits.Code
+		err   bool
+	}{
+		{"EX_NOPERM", sysexits.NoPerm, false},
+		{"tempfail", sysexits.TempFail, false},
+		{"69", sysexits.Unavailable, false},
+		{"0", sysexits.OK, false},
+		{"1", 0, true},
+		{"EX_MISSING", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			code, err := sysexits.ParseCode(tt.input)
+			if tt.err {
+				assert.ErrorIs(t, err, sysexits.ErrInvalidCode)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+
+	assert.Equal(t, "EX_DATAERR", sysexits.DataErr.String())
+	assert.Equal(t, "Code(1)", sysexits.Code(1).String())
+}
@@ -0,0 +1,230 @@
+package code_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JuniorVieira99/jr_httpcodes/codes"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCatalog writes a catalog file with a distinct modification time.
+func writeCatalog(t *testing.T, path, content string, mod time.Time) {
+	t.Helper()
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	assert.NoError(t, os.Chtimes(path, mod, mod))
+}
+
+func TestWatchCatalogReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.yaml")
+	start := time.Now().Add(-time.Hour)
+	writeCatalog(t, path, `status_codes:
+  - code: 721
+    description: First
+  - code: 722
+    description: Second
+methods:
+  - name: PURGE
+    description: Remove a cached resource
+`, start)
+
+	var mux sync.Mutex
+	var seen []codes.CatalogChange
+	w, err := codes.WatchCatalog(path, codes.WatchOptions{
+		Interval: time.Hour,
+		OnChange: func(c codes.CatalogChange) {
+			mux.Lock()
+			seen = append(seen, c)
+			mux.Unlock()
+		},
+	})
+	assert.NoError(t, err)
+	defer func() {
+		w.Catalog().Remove()
+		w.Close()
+	}()
+
+	// First load
+	assert.Equal(t, "First", codes.GetStatusPhrase(721))
+	assert.Len(t, seen, 3)
+	sub := w.Subscribe(8)
+
+	// Unchanged file
+	changes, err := w.Reload()
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// Updated file
+	writeCatalog(t, path, `status_codes:
+  - code: 721
+    description: First, updated
+  - code: 723
+    description: Third
+`, start.Add(time.Minute))
+
+	changes, err = w.Reload()
+	assert.NoError(t, err)
+	assert.Equal(t, []codes.CatalogChange{
+		{Kind: codes.ChangeDescription, Code: 721, Old: "First", New: "First, updated"},
+		{Kind: codes.ChangeRemoved, Code: 722, Old: "Second"},
+		{Kind: codes.ChangeAdded, Code: 723, New: "Third"},
+		{Kind: codes.ChangeRemoved, Method: "PURGE", Old: "Remove a cached resource"},
+	}, changes)
+
+	assert.Equal(t, "First, updated", codes.GetStatusInfo(721))
+	_, ok := codes.LookupStatus(722)
+	assert.False(t, ok)
+	_, ok = codes.LookupStatus(723)
+	assert.True(t, ok)
+	assert.Equal(t, "Unknown Method", codes.GetMethodDescription("PURGE"))
+
+	// Subscribers and callbacks receive the same changes
+	for _, want := range changes {
+		assert.Equal(t, want, <-sub)
+	}
+	mux.Lock()
+	assert.Equal(t, changes, seen[3:])
+	mux.Unlock()
+
+	// Invalid files keep the last valid catalog
+	writeCatalog(t, path, "status_codes:\n  - code: 404\n    description: x\n", start.Add(2*time.Minute))
+	_, err = w.Reload()
+	assert.ErrorIs(t, err, codes.ErrInvalidCatalog)
+	assert.Equal(t, "Third", codes.GetStatusInfo(723))
+
+	// Close
+	assert.NoError(t, w.Close())
+	_, open := <-sub
+	assert.False(t, open)
+	_, err = w.Reload()
+	assert.ErrorIs(t, err, codes.ErrWatcherClosed)
+}
+
+func TestWatchCatalogPolling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	start := time.Now().Add(-time.Hour)
+	writeCatalog(t, path, `{"status_codes": [{"code": 731, "description": "Polled"}]}`, start)
+
+	w, err := codes.WatchCatalog(path, codes.WatchOptions{Interval: 5 * time.Millisecond})
+	assert.NoError(t, err)
+	defer func() {
+		w.Close()
+		w.Catalog().Remove()
+	}()
+	sub := w.Subscribe(8)
+
+	writeCatalog(t, path, `{"status_codes": [{"code": 731, "description": "Polled again"}]}`, start.Add(time.Minute))
+
+	select {
+	case change := <-sub:
+		assert.Equal(t, codes.ChangeDescription, change.Kind)
+		assert.Equal(t, codes.Description("Polled again"), change.New)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change received")
+	}
+}
+
+func TestWatchCatalogOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	start := time.Now().Add(-time.Hour)
+	writeCatalog(t, path, `{"status_codes": [{"code": 741, "description": "Valid"}]}`, start)
+
+	errs := make(chan error, 8)
+	w, err := codes.WatchCatalog(path, codes.WatchOptions{
+		Interval: 2 * time.Millisecond,
+		OnError:  func(err error) { errs <- err },
+	})
+	assert.NoError(t, err)
+	defer w.Catalog().Remove()
+
+	// An invalid file is reported once, not on every poll
+	writeCatalog(t, path, `{"status_codes": [{"code": 404, "description": "x"}]}`, start.Add(time.Minute))
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, codes.ErrInvalidCatalog)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no error reported")
+	}
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, errs)
+
+	// Reload returns the error again
+	_, err = w.Reload()
+	assert.ErrorIs(t, err, codes.ErrInvalidCatalog)
+	assert.Equal(t, "Valid", codes.GetStatusInfo(741))
+
+	assert.NoError(t, w.Close())
+}
+
+func TestWatchCatalogCloseFromOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	writeCatalog(t, path, `{}`, time.Now().Add(-time.Hour))
+
+	watcher := make(chan *codes.CatalogWatcher, 1)
+	closed := make(chan struct{})
+	w, err := codes.WatchCatalog(path, codes.WatchOptions{
+		Interval: 2 * time.Millisecond,
+		OnError: func(error) {
+			(<-watcher).Close()
+			close(closed)
+		},
+	})
+	assert.NoError(t, err)
+	watcher <- w
+
+	// A missing file is an error
+	assert.NoError(t, os.Remove(path))
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close from OnError did not return")
+	}
+	_, err = w.Reload()
+	assert.ErrorIs(t, err, codes.ErrWatcherClosed)
+}
+
+func TestWatchCatalogConcurrentLookups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.yaml")
+	start := time.Now().Add(-time.Hour)
+	writeCatalog(t, path, "status_codes:\n  - code: 751\n    description: Racy\n", start)
+
+	w, err := codes.WatchCatalog(path, codes.WatchOptions{Interval: time.Hour})
+	assert.NoError(t, err)
+	defer func() {
+		w.Close()
+		w.Catalog().Remove()
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			codes.GetStatusPhrase(751)
+			codes.GetStatusInfo(751)
+			codes.IsSafeMethod("PURGE")
+			codes.GetMethodDescription("PURGE")
+		}
+	}()
+	for i := 1; i <= 20; i++ {
+		content := "status_codes:\n  - code: 751\n    description: Racy\n"
+		if i%2 == 1 {
+			content = "methods:\n  - name: PURGE\n    description: Purge\n    safe: true\n"
+		}
+		writeCatalog(t, path, content, start.Add(time.Duration(i)*time.Minute))
+		_, err := w.Reload()
+		assert.NoError(t, err)
+	}
+	<-done
+}
+
+func TestWatchCatalogErrors(t *testing.T) {
+	_, err := codes.WatchCatalog(filepath.Join(t.TempDir(), "missing.yaml"), codes.WatchOptions{})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	assert.Equal(t, "added", codes.ChangeAdded.String())
+	assert.Equal(t, "removed", codes.ChangeRemoved.String())
+	assert.Equal(t, "description", codes.ChangeDescription.String())
+}